@@ -0,0 +1,61 @@
+package bark
+
+import "sync"
+
+var (
+	baggageMu sync.RWMutex
+	baggage   []any
+)
+
+// SetBaggageFields stores keyvals globally and attaches them to every log entry
+// until ClearBaggageFields is called. Each call replaces the previous baggage.
+// Baggage is added before call-site fields, so a call-site key of the same name wins.
+func SetBaggageFields(keyvals ...any) {
+	baggageMu.Lock()
+	defer baggageMu.Unlock()
+	baggage = append([]any(nil), keyvals...)
+}
+
+// ClearBaggageFields removes all baggage fields set by SetBaggageFields.
+func ClearBaggageFields() {
+	baggageMu.Lock()
+	defer baggageMu.Unlock()
+	baggage = nil
+}
+
+// baggageFields returns a copy of the current baggage keyvals.
+func baggageFields() []any {
+	baggageMu.RLock()
+	defer baggageMu.RUnlock()
+	return append([]any(nil), baggage...)
+}
+
+// dedupeFields drops any key/value pair whose key appears again later in
+// keyvals, so that the most specific value for a key is the one emitted.
+func dedupeFields(keyvals []any) []any {
+	if len(keyvals) < 4 {
+		return keyvals
+	}
+
+	deduped := make([]any, 0, len(keyvals))
+	for i := 0; i < len(keyvals); i += 2 {
+		if i+1 >= len(keyvals) {
+			deduped = append(deduped, keyvals[i])
+			break
+		}
+
+		key, ok := keyvals[i].(string)
+		shadowed := false
+		for j := i + 2; ok && j+1 < len(keyvals); j += 2 {
+			if later, _ := keyvals[j].(string); later == key {
+				shadowed = true
+				break
+			}
+		}
+		if !shadowed {
+			deduped = append(deduped, keyvals[i], keyvals[i+1])
+		}
+	}
+
+	return deduped
+}
@@ -0,0 +1,101 @@
+package bark
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Banner writes text to every sink as a banner, e.g. an app's name at startup.
+// On terminals it is centered in the terminal's width and, given truecolor support,
+// colored with a horizontal gradient from fromHex to toHex; both are hex strings,
+// ANSI indices, or color names. Other terminals, files, and invalid colors get plain text.
+// Like Print, pretty sinks show just the banner, structured sinks write text as
+// an entry at the Print level, and levels, quiet mode, and filters are ignored.
+// Multi-line text stays aligned, with the gradient running across the widest line.
+func Banner(text, fromHex, toHex string) {
+	from, fromOK := normalizeColor(fromHex)
+	to, toOK := normalizeColor(toHex)
+	gradient := fromOK && toOK
+
+	offset := -1
+	for _, s := range currentSinks() {
+		if s.reportCaller && offset < 0 {
+			offset = callerOffset()
+		}
+		if s.format.structured() {
+			s.print(text, offset)
+			continue
+		}
+
+		s.writeLine(PrintLevel, text, nil, func() string {
+			lines := strings.Split(text, "\n")
+			if gradient && s.renderer.ColorProfile() == termenv.TrueColor {
+				lines = gradientLines(s.renderer, lines, from, to)
+			}
+			if s.terminal() {
+				lines = centerLines(lines, lineWidth(s.out))
+			}
+			return strings.Join(lines, "\n")
+		})
+	}
+}
+
+// gradientLines colors the runes of lines with a gradient from one color to another,
+// giving runes in the same column the same color.
+func gradientLines(r *lipgloss.Renderer, lines []string, from, to string) []string {
+	r1, g1, b1, err := colorRGB(from)
+	if err != nil {
+		return lines
+	}
+	r2, g2, b2, err := colorRGB(to)
+	if err != nil {
+		return lines
+	}
+
+	width := 0
+	for _, line := range lines {
+		width = max(width, lipgloss.Width(line))
+	}
+
+	colored := make([]string, len(lines))
+	for i, line := range lines {
+		var b strings.Builder
+		col := 0
+		for _, c := range line {
+			// Position the color at the rune's column, from 0 at the start to 1 at the end.
+			t := 0.0
+			if width > 1 {
+				t = float64(col) / float64(width-1)
+			}
+			hex := fmt.Sprintf("#%02x%02x%02x", lerp(r1, r2, t), lerp(g1, g2, t), lerp(b1, b2, t))
+			b.WriteString(r.NewStyle().Foreground(lipgloss.Color(hex)).Render(string(c)))
+			col += lipgloss.Width(string(c))
+		}
+		colored[i] = b.String()
+	}
+	return colored
+}
+
+// lerp interpolates between the channel values a and b, t of the way from a to b.
+func lerp(a, b uint8, t float64) uint8 {
+	return uint8(float64(a) + (float64(b)-float64(a))*t + 0.5)
+}
+
+// centerLines pads lines on the left to center them, as a block, in width columns.
+// Lines too wide to center are left as they are.
+func centerLines(lines []string, width int) []string {
+	widest := 0
+	for _, line := range lines {
+		widest = max(widest, lipgloss.Width(line))
+	}
+
+	pad := strings.Repeat(" ", max(width-widest, 0)/2)
+	centered := make([]string, len(lines))
+	for i, line := range lines {
+		centered[i] = pad + line
+	}
+	return centered
+}
@@ -1,189 +1,249 @@
 // Package bark provides a colorful and stylish logging interface
 // built on top of Charmbracelet's log and lipgloss packages.
-// It supports Info, Warn, Error, Debug, and Fatal levels, with custom colors and formats.
+// It supports Trace, Debug, Info, Success, Notice, Warn, Error, Panic, and Fatal levels,
+// with custom colors and formats.
 package bark
 
 import (
-	"errors"
 	"fmt"
-	"os"
-
-	"github.com/charmbracelet/lipgloss"
-	"github.com/charmbracelet/log"
+	"sync"
 )
 
-var defaultOptions BarkOptions = BarkOptions{
-	InfoHex:  "#1982c4",
-	WarnHex:  "#ffca3a",
-	ErrorHex: "#ff595e",
-	DebugHex: "#ca7df9",
-
-	TimeFormat: "01/02 03:04:05PM",
-}
-
-var loggers []*log.Logger
-
-// BarkOptions specifies configuration for colors and time formatting.
-type BarkOptions struct {
-	InfoHex  string
-	WarnHex  string
-	ErrorHex string
-	DebugHex string
+var (
+	sinksMu  sync.RWMutex
+	sinks    []*sink
+	autoInit = &sync.Once{}
+	// activeConfig is the configuration of the last successful Init.
+	activeConfig config
+)
 
-	TimeFormat string
+// Init initializes the logging system with the provided Options, applied in order.
+// Either functional options or a BarkOptions struct may be passed:
+//
+//	bark.Init(bark.WithInfoColor("#1982c4"), bark.WithTimeFormat(time.Kitchen))
+//	bark.Init(bark.BarkOptions{InfoHex: "#1982c4"})
+//
+// Anything not set keeps its default.
+// If Init is never called, the first log call initializes bark with the defaults,
+// and a later Init replaces that default configuration.
+//
+// The global level starts out from the BARK_LEVEL environment variable, if set;
+// see WithLevelEnv.
+//
+// If any option is invalid, Init returns an error naming every invalid
+// option and leaves the current configuration untouched.
+func Init(opts ...Option) error {
+	return initialize(false, opts...)
 }
 
-func mergeOpts(opts BarkOptions) BarkOptions {
-	merge := BarkOptions{}
-
-	if opts.InfoHex != "" {
-		merge.InfoHex = opts.InfoHex
-	} else {
-		merge.InfoHex = defaultOptions.InfoHex
+// initialize implements Init. When auto is true, bark is initializing itself
+// on the first log call, so a level already set with SetLevel is kept.
+func initialize(auto bool, opts ...Option) error {
+	cfg, err := newConfig(opts...)
+	if err != nil {
+		return err
 	}
 
-	if opts.WarnHex != "" {
-		merge.WarnHex = opts.WarnHex
-	} else {
-		merge.WarnHex = defaultOptions.WarnHex
-	}
+	sinksMu.Lock()
+	sinks = []*sink{newSink(cfg.output, cfg)}
+	activeConfig = cfg
+	initialized := sinks
+	sinksMu.Unlock()
 
-	if opts.ErrorHex != "" {
-		merge.ErrorHex = opts.ErrorHex
-	} else {
-		merge.ErrorHex = defaultOptions.ErrorHex
+	if !auto || !levelSet.Load() {
+		applyLevelEnv(cfg.levelEnv, initialized)
 	}
-
-	if opts.DebugHex != "" {
-		merge.DebugHex = opts.DebugHex
-	} else {
-		merge.DebugHex = defaultOptions.DebugHex
+	// Don't let auto-initialization undo an earlier SetQuiet.
+	if !auto || cfg.quiet {
+		SetQuiet(cfg.quiet)
 	}
-
-	if opts.TimeFormat != "" {
-		merge.TimeFormat = opts.TimeFormat
-	} else {
-		merge.TimeFormat = defaultOptions.TimeFormat
+	if !auto {
+		ResetCounters()
+		ResetByteCounters()
 	}
+	updateMinLevel()
 
-	return merge
+	return nil
 }
 
-// Init initializes the logging system with the provided BarkOptions.
-// If any fields are omitted, defaults are used.
-// This must be called before using the other logging functions.
-func Init(opts BarkOptions) {
-	mergedOpts := mergeOpts(opts)
-
-	loggers = make([]*log.Logger, 0)
-
-	stdLogger := log.New(os.Stderr)
-	styles := log.DefaultStyles()
-
-	styles.Levels[log.InfoLevel] = lipgloss.NewStyle().SetString(" INFO ").Padding(0, 1).Foreground(lipgloss.Color(mergedOpts.InfoHex)).Bold(true)
-	styles.Levels[log.WarnLevel] = lipgloss.NewStyle().SetString(" WARN ").Padding(0, 1).Foreground(lipgloss.Color(mergedOpts.WarnHex)).Bold(true)
-	styles.Levels[log.ErrorLevel] = lipgloss.NewStyle().SetString("ERROR ").Padding(0, 1).Foreground(lipgloss.Color(mergedOpts.ErrorHex)).Bold(true)
-	styles.Levels[log.FatalLevel] = lipgloss.NewStyle().SetString("FATAL ").Padding(0, 1).Foreground(lipgloss.Color(mergedOpts.ErrorHex)).Bold(true)
-	styles.Levels[log.DebugLevel] = lipgloss.NewStyle().SetString("DEBUG ").Padding(0, 1).Foreground(lipgloss.Color(mergedOpts.DebugHex)).Bold(true)
+// currentSinks returns the configured sinks, initializing bark
+// with the defaults first if Init has not been called yet.
+func currentSinks() []*sink {
+	sinksMu.RLock()
+	once := autoInit
+	sinksMu.RUnlock()
+
+	once.Do(func() {
+		sinksMu.RLock()
+		initialized := sinks != nil
+		sinksMu.RUnlock()
+
+		if !initialized {
+			initialize(true)
+		}
+	})
+
+	sinksMu.RLock()
+	defer sinksMu.RUnlock()
+	return sinks
+}
 
-	stdLogger.SetStyles(styles)
-	stdLogger.SetTimeFormat(mergedOpts.TimeFormat)
-	stdLogger.SetReportTimestamp(true)
+// MustInit is like Init but panics if any option is invalid,
+// for callers that don't want to handle the error.
+func MustInit(opts ...Option) {
+	if err := Init(opts...); err != nil {
+		panic("bark: " + err.Error())
+	}
+}
 
-	loggers = append(loggers, stdLogger)
+// SetLevel sets the global level: entries below it are dropped by every Logger
+// that has no level of its own, whether set directly or through a level rule.
+func SetLevel(level Level) {
+	globalLevel.Store(int64(level))
+	levelSet.Store(true)
+	updateMinLevel()
 }
 
 // SetDebugLevel sets the log verbosity.
 // When v is true, debug messages are shown. Otherwise, only Info and above are logged.
+//
+// Deprecated: Use SetLevel(DebugLevel) or SetLevel(InfoLevel).
 func SetDebugLevel(v bool) {
-	var level log.Level
 	if v {
-		level = log.DebugLevel
+		SetLevel(DebugLevel)
 	} else {
-		level = log.InfoLevel
+		SetLevel(InfoLevel)
 	}
+}
 
-	for _, logger := range loggers {
-		logger.SetLevel(level)
+// SetTraceLevel sets the log verbosity independently of SetDebugLevel.
+// When v is true, trace and debug messages are shown. Otherwise, only Info and above are logged.
+//
+// Deprecated: Use SetLevel(TraceLevel) or SetLevel(InfoLevel).
+func SetTraceLevel(v bool) {
+	if v {
+		SetLevel(TraceLevel)
+	} else {
+		SetLevel(InfoLevel)
 	}
 }
 
 // Info logs a message at Info level.
-func Info(msg string) {
-	for _, logger := range loggers {
-		logger.Info(msg)
-	}
+func Info(msg string, keyvals ...any) {
+	std.log(InfoLevel, msg, keyvals...)
 }
 
 // Info logs a formatted message at Info level.
 func Infof(formatMsg string, vals ...any) {
-	for _, logger := range loggers {
-		logger.Infof(formatMsg, vals...)
-	}
+	std.logf(InfoLevel, formatMsg, vals...)
+}
+
+// Success logs a positive completion message. It is filtered like Info.
+func Success(msg string, keyvals ...any) {
+	std.log(SuccessLevel, msg, keyvals...)
+}
+
+// Successf logs a formatted positive completion message. It is filtered like Info.
+func Successf(formatMsg string, vals ...any) {
+	std.logf(SuccessLevel, formatMsg, vals...)
+}
+
+// Notice logs a message at Notice level, between Info and Warn.
+func Notice(msg string, keyvals ...any) {
+	std.log(NoticeLevel, msg, keyvals...)
+}
+
+// Noticef logs a formatted message at Notice level, between Info and Warn.
+func Noticef(formatMsg string, vals ...any) {
+	std.logf(NoticeLevel, formatMsg, vals...)
 }
 
 // Warn logs a message at Warn level.
-func Warn(msg string) {
-	for _, logger := range loggers {
-		logger.Warn(msg)
-	}
+func Warn(msg string, keyvals ...any) {
+	std.log(WarnLevel, msg, keyvals...)
 }
 
 // Warnf logs a formatted message at Warn level.
 func Warnf(formatMsg string, vals ...any) {
-	for _, logger := range loggers {
-		logger.Warnf(formatMsg, vals...)
-	}
+	std.logf(WarnLevel, formatMsg, vals...)
 }
 
 // Error logs a message at Error level.
-func Error(msg string) {
-	for _, logger := range loggers {
-		logger.Error(msg)
-	}
+func Error(msg string, keyvals ...any) {
+	std.log(ErrorLevel, msg, keyvals...)
 }
 
 // Errorf logs a formatted message at Error level.
 func Errorf(formatMsg string, vals ...any) {
-	for _, logger := range loggers {
-		logger.Errorf(formatMsg, vals...)
-	}
+	std.logf(ErrorLevel, formatMsg, vals...)
 }
 
 // Fatal logs a message at Fatal level and terminates the program.
-func Fatal(msg string) {
-	for _, logger := range loggers {
-		logger.Fatal(msg)
-	}
+func Fatal(msg string, keyvals ...any) {
+	std.log(FatalLevel, msg, keyvals...)
+	std.exit(msg)
 }
 
 // Fatalf logs a formatted message at Fatal level and terminates the program.
 func Fatalf(formatMsg string, vals ...any) {
-	for _, logger := range loggers {
-		logger.Fatalf(formatMsg, vals...)
-	}
+	msg := std.logf(FatalLevel, formatMsg, vals...)
+	std.exit(msg)
+}
+
+// Panic logs a message at Panic level, flushes the sinks, and panics with msg,
+// so deferred functions still run and callers can recover.
+func Panic(msg string, keyvals ...any) {
+	std.log(PanicLevel, msg, keyvals...)
+	Flush()
+	panic(msg)
+}
+
+// Panicf logs a formatted message at Panic level, flushes the sinks,
+// and panics with the formatted message.
+func Panicf(formatMsg string, vals ...any) {
+	msg := std.logf(PanicLevel, formatMsg, vals...)
+	Flush()
+	panic(msg)
+}
+
+// Trace logs a message at Trace level, below Debug.
+func Trace(msg string, keyvals ...any) {
+	std.log(TraceLevel, msg, keyvals...)
+}
+
+// Tracef logs a formatted message at Trace level, below Debug.
+func Tracef(formatMsg string, vals ...any) {
+	std.logf(TraceLevel, formatMsg, vals...)
 }
 
 // Debug logs a message at Debug level.
-func Debug(msg string) {
-	for _, logger := range loggers {
-		logger.Debug(msg)
-	}
+func Debug(msg string, keyvals ...any) {
+	std.log(DebugLevel, msg, keyvals...)
 }
 
 // Debugf logs a formatted message at Debug level.
 func Debugf(formatMsg string, vals ...any) {
-	for _, logger := range loggers {
-		logger.Debugf(formatMsg, vals...)
-	}
+	std.logf(DebugLevel, formatMsg, vals...)
+}
+
+// Print writes the program's output, formatted as by fmt.Sprint, to the primary sink,
+// e.g. a table or URL a command line tool produces. Pretty sinks show just the
+// message, without level badge, timestamp, or fields; structured sinks write
+// it with the level "print". Print ignores levels, quiet mode, and filters.
+func Print(vals ...any) {
+	std.print(fmt.Sprint(vals...))
+}
+
+// Printf writes the program's output, formatted as by fmt.Sprintf, to the primary sink.
+// See Print.
+func Printf(formatMsg string, vals ...any) {
+	std.print(fmt.Sprintf(formatMsg, vals...))
 }
 
 // DebugAndWait logs a Debug message and waits for the user to press Enter.
 // Useful for debugging program flow.
 func DebugAndWait(msg string) {
-	for _, logger := range loggers {
-		logger.Debug(fmt.Sprintf("%v (󰌑)", msg))
-	}
+	std.log(DebugLevel, fmt.Sprintf("%v (󰌑)", msg))
 
 	fmt.Scanln()
 }
@@ -191,19 +251,7 @@ func DebugAndWait(msg string) {
 // DebugfAndWait logs a formatted Debug message and waits for the user to press Enter.
 // Useful for debugging program flow.
 func DebugfAndWait(formatMsg string, vals ...any) {
-	for _, logger := range loggers {
-		logger.Debugf(fmt.Sprintf("%v (󰌑)", formatMsg), vals...)
-	}
+	std.log(DebugLevel, fmt.Sprintf(fmt.Sprintf("%v (󰌑)", formatMsg), vals...))
 
 	fmt.Scanln()
 }
-
-// NewError creates a new error with the provided message.
-func NewError(msg string) error {
-	return errors.New(msg)
-}
-
-// NewErrorf creates a new formatted error using the format string and arguments.
-func NewErrorf(formatMsg string, vals ...any) error {
-	return fmt.Errorf(formatMsg, vals...)
-}
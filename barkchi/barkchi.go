@@ -0,0 +1,50 @@
+// Package barkchi logs requests to chi routers through bark.
+package barkchi
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.dalton.dog/bark"
+)
+
+// ChiMiddleware logs every request handled by next once it completes, with the
+// fields method, path, route, status, duration_ms, and remote_addr. route is the
+// pattern chi matched, such as "/users/{id}", so requests can be grouped by
+// endpoint whatever their path. Responses with a status of 500 or above are
+// logged at Error level, everything else at Info.
+func ChiMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		next.ServeHTTP(ww, r)
+
+		// The pattern is only complete once routing has finished.
+		route := ""
+		if rctx := chi.RouteContext(r.Context()); rctx != nil {
+			route = rctx.RoutePattern()
+		}
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		keyvals := []any{
+			"method", r.Method,
+			"path", r.URL.Path,
+			"route", route,
+			"status", status,
+			"duration_ms", time.Since(start).Milliseconds(),
+			"remote_addr", r.RemoteAddr,
+		}
+		if status >= http.StatusInternalServerError {
+			bark.Error("request failed", keyvals...)
+		} else {
+			bark.Info("request", keyvals...)
+		}
+	})
+}
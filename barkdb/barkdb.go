@@ -0,0 +1,315 @@
+// Package barkdb logs database/sql queries through bark by wrapping a driver.
+package barkdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"time"
+
+	"go.dalton.dog/bark"
+)
+
+// DBLogOptions configures the logging done by a wrapped driver.
+type DBLogOptions struct {
+	// LogArgs logs the values of query arguments. By default only their number
+	// is logged, since arguments often carry personal data or secrets.
+	LogArgs bool
+
+	// MinDuration is the shortest call that is logged; faster ones are skipped.
+	// Failed calls are always logged.
+	MinDuration time.Duration
+
+	// SlowThreshold is the duration at or above which a call is logged at SlowLevel
+	// instead of Debug. Zero disables slow query reporting.
+	SlowThreshold time.Duration
+	// SlowLevel, if set, is the level slow calls are logged at. The default is WarnLevel.
+	SlowLevel *bark.Level
+}
+
+// WrapDriver returns a driver that passes every call to d, logging each prepare,
+// query, and exec with the fields query, duration_ms, and either args or arg_count.
+// Calls are logged at Debug level, slow ones at Warn level or opts.SlowLevel, and
+// failed ones at Error level with the error. Register the wrapped driver under its own name:
+//
+//	sql.Register("postgres-logged", barkdb.WrapDriver(&pq.Driver{}, barkdb.DBLogOptions{
+//		SlowThreshold: 200 * time.Millisecond,
+//	}))
+//	db, err := sql.Open("postgres-logged", dsn)
+func WrapDriver(d driver.Driver, opts DBLogOptions) driver.Driver {
+	// Copy the level, so the caller changing it afterwards has no effect.
+	if opts.SlowLevel != nil {
+		level := *opts.SlowLevel
+		opts.SlowLevel = &level
+	}
+	return &wrappedDriver{driver: d, opts: opts}
+}
+
+// wrappedDriver is the driver.Driver returned by WrapDriver.
+type wrappedDriver struct {
+	driver driver.Driver
+	opts   DBLogOptions
+}
+
+func (d *wrappedDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &wrappedConn{conn: conn, opts: &d.opts}, nil
+}
+
+func (d *wrappedDriver) OpenConnector(name string) (driver.Connector, error) {
+	if dc, ok := d.driver.(driver.DriverContext); ok {
+		connector, err := dc.OpenConnector(name)
+		if err != nil {
+			return nil, err
+		}
+		return &wrappedConnector{connector: connector, driver: d}, nil
+	}
+	return &wrappedConnector{name: name, driver: d}, nil
+}
+
+// wrappedConnector wraps the connections of the underlying driver's Connector,
+// or opens them by name if the driver doesn't provide one.
+type wrappedConnector struct {
+	connector driver.Connector
+	name      string
+	driver    *wrappedDriver
+}
+
+func (c *wrappedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	if c.connector == nil {
+		return c.driver.Open(c.name)
+	}
+
+	conn, err := c.connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &wrappedConn{conn: conn, opts: &c.driver.opts}, nil
+}
+
+func (c *wrappedConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// wrappedConn logs the statements run on a connection.
+type wrappedConn struct {
+	conn driver.Conn
+	opts *DBLogOptions
+}
+
+func (c *wrappedConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *wrappedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	start := time.Now()
+
+	var stmt driver.Stmt
+	var err error
+	if pc, ok := c.conn.(driver.ConnPrepareContext); ok {
+		stmt, err = pc.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.conn.Prepare(query)
+	}
+
+	logCall(c.opts, "prepare", query, nil, start, err)
+	if err != nil {
+		return nil, err
+	}
+	return &wrappedStmt{stmt: stmt, query: query, opts: c.opts}, nil
+}
+
+func (c *wrappedConn) Close() error {
+	return c.conn.Close()
+}
+
+func (c *wrappedConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *wrappedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if bc, ok := c.conn.(driver.ConnBeginTx); ok {
+		return bc.BeginTx(ctx, opts)
+	}
+
+	// Like database/sql, refuse options that Begin can't honor rather than drop them.
+	if opts.Isolation != driver.IsolationLevel(sql.LevelDefault) {
+		return nil, errors.New("barkdb: driver does not support non-default isolation level")
+	}
+	if opts.ReadOnly {
+		return nil, errors.New("barkdb: driver does not support read-only transactions")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.conn.Begin()
+}
+
+func (c *wrappedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	ec, ok := c.conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	start := time.Now()
+	result, err := ec.ExecContext(ctx, query, args)
+	logCall(c.opts, "exec", query, args, start, err)
+	return result, err
+}
+
+func (c *wrappedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	qc, ok := c.conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	start := time.Now()
+	rows, err := qc.QueryContext(ctx, query, args)
+	logCall(c.opts, "query", query, args, start, err)
+	return rows, err
+}
+
+func (c *wrappedConn) Ping(ctx context.Context) error {
+	if p, ok := c.conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *wrappedConn) ResetSession(ctx context.Context) error {
+	if r, ok := c.conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *wrappedConn) IsValid() bool {
+	if v, ok := c.conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
+func (c *wrappedConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if nc, ok := c.conn.(driver.NamedValueChecker); ok {
+		return nc.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+// wrappedStmt logs the executions of a prepared statement.
+type wrappedStmt struct {
+	stmt  driver.Stmt
+	query string
+	opts  *DBLogOptions
+}
+
+func (s *wrappedStmt) Close() error {
+	return s.stmt.Close()
+}
+
+func (s *wrappedStmt) NumInput() int {
+	return s.stmt.NumInput()
+}
+
+func (s *wrappedStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), namedValues(args))
+}
+
+func (s *wrappedStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), namedValues(args))
+}
+
+func (s *wrappedStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	start := time.Now()
+
+	var result driver.Result
+	var err error
+	if ec, ok := s.stmt.(driver.StmtExecContext); ok {
+		result, err = ec.ExecContext(ctx, args)
+	} else {
+		result, err = s.stmt.Exec(values(args))
+	}
+
+	logCall(s.opts, "exec", s.query, args, start, err)
+	return result, err
+}
+
+func (s *wrappedStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	start := time.Now()
+
+	var rows driver.Rows
+	var err error
+	if qc, ok := s.stmt.(driver.StmtQueryContext); ok {
+		rows, err = qc.QueryContext(ctx, args)
+	} else {
+		rows, err = s.stmt.Query(values(args))
+	}
+
+	logCall(s.opts, "query", s.query, args, start, err)
+	return rows, err
+}
+
+func (s *wrappedStmt) CheckNamedValue(nv *driver.NamedValue) error {
+	if nc, ok := s.stmt.(driver.NamedValueChecker); ok {
+		return nc.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+// logCall logs one call made through a wrapped driver, according to opts.
+func logCall(opts *DBLogOptions, op, query string, args []driver.NamedValue, start time.Time, err error) {
+	// ErrSkip asks database/sql to try another way, so nothing was run.
+	if errors.Is(err, driver.ErrSkip) {
+		return
+	}
+
+	duration := time.Since(start)
+	if err == nil && duration < opts.MinDuration {
+		return
+	}
+
+	keyvals := []any{"query", query, "duration_ms", duration.Milliseconds()}
+	if op != "prepare" {
+		if opts.LogArgs {
+			keyvals = append(keyvals, "args", values(args))
+		} else {
+			keyvals = append(keyvals, "arg_count", len(args))
+		}
+	}
+
+	switch {
+	case err != nil:
+		bark.Error(op+" failed", append(keyvals, "error", err)...)
+	case opts.SlowThreshold > 0 && duration >= opts.SlowThreshold:
+		level := bark.WarnLevel
+		if opts.SlowLevel != nil {
+			level = *opts.SlowLevel
+		}
+		bark.Log(level, "slow "+op, keyvals...)
+	default:
+		bark.Debug(op, keyvals...)
+	}
+}
+
+// namedValues converts positional arguments to named ones.
+func namedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+	}
+	return named
+}
+
+// values returns the values of named arguments.
+func values(args []driver.NamedValue) []driver.Value {
+	plain := make([]driver.Value, len(args))
+	for i, arg := range args {
+		plain[i] = arg.Value
+	}
+	return plain
+}
@@ -0,0 +1,75 @@
+// Package barkecho logs requests to Echo servers through bark.
+package barkecho
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.dalton.dog/bark"
+)
+
+// EchoMiddleware returns middleware that logs every request once it completes,
+// with the fields method, uri, status, and latency_ms. Requests with a status of
+// 500 or above are logged at Error level, everything else at Info.
+//
+// A handler's error is passed to Echo's HTTPErrorHandler first,
+// so the logged status is the one sent to the client.
+func EchoMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+
+			err := next(c)
+			if err != nil {
+				c.Error(err)
+			}
+
+			req := c.Request()
+			status := c.Response().Status
+			keyvals := []any{
+				"method", req.Method,
+				"uri", req.RequestURI,
+				"status", status,
+				"latency_ms", time.Since(start).Milliseconds(),
+			}
+			if err != nil {
+				keyvals = append(keyvals, "error", err)
+			}
+
+			if status >= http.StatusInternalServerError {
+				bark.Error("request failed", keyvals...)
+			} else {
+				bark.Info("request", keyvals...)
+			}
+
+			// The error has been handled, so Echo mustn't handle it again.
+			return nil
+		}
+	}
+}
+
+// RecoverLogErrorFunc logs a panic recovered by Echo's Recover middleware at Error
+// level, with the request and stack. If a hook was added with bark.AddPostHook,
+// the panic is logged at Fatal level instead, without exiting, so the hook can
+// tell it apart and raise an alert. Use it as the LogErrorFunc of
+// middleware.RecoverConfig:
+//
+//	e.Use(middleware.RecoverWithConfig(middleware.RecoverConfig{LogErrorFunc: barkecho.RecoverLogErrorFunc}))
+func RecoverLogErrorFunc(c echo.Context, err error, stack []byte) error {
+	level := bark.ErrorLevel
+	if bark.HasPostHooks() {
+		level = bark.FatalLevel
+	}
+
+	req := c.Request()
+	bark.Log(level, "recovered from panic",
+		"method", req.Method,
+		"uri", req.RequestURI,
+		"error", err,
+		"stack", string(stack),
+	)
+
+	// Let Recover pass the error on to Echo's HTTPErrorHandler.
+	return err
+}
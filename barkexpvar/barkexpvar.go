@@ -0,0 +1,34 @@
+// Package barkexpvar publishes counts of bark entries through expvar, so they
+// appear alongside a program's other variables at /debug/vars.
+//
+// It is separate from bark because importing expvar registers the /debug/vars
+// handler on http.DefaultServeMux.
+package barkexpvar
+
+import (
+	"expvar"
+	"sync"
+
+	"go.dalton.dog/bark"
+)
+
+// countedLevels are the levels published in bark_log_counts.
+var countedLevels = []bark.Level{bark.DebugLevel, bark.InfoLevel, bark.WarnLevel, bark.ErrorLevel, bark.FatalLevel}
+
+var once sync.Once
+
+// RegisterExpvar publishes bark_log_counts, an expvar.Map with the counts of entries
+// logged at the debug, info, warn, error, and fatal levels, keyed by level name.
+// The values are read from bark.LogCounter whenever the map is, so they are
+// always current and follow bark.ResetCounters. Calling it again has no effect.
+func RegisterExpvar() {
+	once.Do(func() {
+		counts := new(expvar.Map)
+		for _, level := range countedLevels {
+			counts.Set(bark.LevelName(level), expvar.Func(func() any {
+				return bark.LogCounter()[level]
+			}))
+		}
+		expvar.Publish("bark_log_counts", counts)
+	})
+}
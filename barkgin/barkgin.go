@@ -0,0 +1,70 @@
+// Package barkgin logs requests to Gin servers through bark.
+package barkgin
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.dalton.dog/bark"
+)
+
+// Option configures GinMiddleware.
+type Option func(m *middleware)
+
+// WithSkipPaths stops requests to any of paths, such as health checks,
+// from being logged. Paths are compared exactly against the request path.
+func WithSkipPaths(paths ...string) Option {
+	return func(m *middleware) {
+		for _, path := range paths {
+			m.skip[path] = struct{}{}
+		}
+	}
+}
+
+// middleware holds GinMiddleware's configuration.
+type middleware struct {
+	skip map[string]struct{}
+}
+
+// GinMiddleware returns middleware that logs every request once it completes,
+// with the fields method, path, status, and latency_ms, in place of gin.Logger.
+// Requests with a status of 500 or above are logged at Error level along with
+// any errors attached to the context, everything else at Info:
+//
+//	r := gin.New()
+//	r.Use(barkgin.GinMiddleware(barkgin.WithSkipPaths("/healthz")), gin.Recovery())
+func GinMiddleware(opts ...Option) gin.HandlerFunc {
+	m := &middleware{skip: map[string]struct{}{}}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return func(c *gin.Context) {
+		path := c.Request.URL.Path
+		if _, ok := m.skip[path]; ok {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		keyvals := []any{
+			"method", c.Request.Method,
+			"path", path,
+			"status", status,
+			"latency_ms", time.Since(start).Milliseconds(),
+		}
+		if len(c.Errors) > 0 {
+			keyvals = append(keyvals, "errors", c.Errors.String())
+		}
+
+		if status >= http.StatusInternalServerError {
+			bark.Error("request failed", keyvals...)
+		} else {
+			bark.Info("request", keyvals...)
+		}
+	}
+}
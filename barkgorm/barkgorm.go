@@ -0,0 +1,137 @@
+// Package barkgorm routes GORM's logging through bark.
+package barkgorm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.dalton.dog/bark"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/utils"
+)
+
+// SlowThreshold is the duration at or above which queries are logged at Warn level.
+const SlowThreshold = 200 * time.Millisecond
+
+// gormLogger implements logger.Interface on top of bark.
+type gormLogger struct {
+	// logger is the Logger built from the options given to NewGORMLogger,
+	// or nil to use the Logger in each call's context.
+	logger *bark.Logger
+	mode   logger.LogLevel
+}
+
+// NewGORMLogger returns a GORM logger that writes through bark:
+//
+//	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: barkgorm.NewGORMLogger(bark.BarkOptions{})})
+//
+// With empty opts, entries go to the Logger in each call's context, as stored by
+// bark.WithContext, and otherwise to the package-level configuration. Other opts
+// give GORM a Logger of its own, as created by bark.New; if they are invalid,
+// a Warn entry says so and the package-level configuration is used instead.
+//
+// GORM's Info, Warn, and Error calls map onto bark's levels, as do executed
+// queries: failed ones are logged at Error level, except for gorm.ErrRecordNotFound,
+// ones taking SlowThreshold or longer at Warn level, and the rest at Info level.
+// The LogMode GORM requests sets the least severe of these that is logged,
+// starting at Warn, on top of bark's own level.
+func NewGORMLogger(opts bark.BarkOptions) logger.Interface {
+	l := &gormLogger{mode: logger.Warn}
+	if opts == (bark.BarkOptions{}) {
+		return l
+	}
+
+	custom, err := bark.New(opts)
+	if err != nil {
+		bark.Warn("barkgorm: using the default configuration", "error", err)
+		return l
+	}
+	l.logger = custom
+
+	return l
+}
+
+// LogMode returns a copy of l that logs according to mode.
+func (l *gormLogger) LogMode(mode logger.LogLevel) logger.Interface {
+	copied := *l
+	copied.mode = mode
+	return &copied
+}
+
+func (l *gormLogger) Info(ctx context.Context, msg string, data ...any) {
+	l.log(ctx, bark.InfoLevel, fmt.Sprintf(msg, data...))
+}
+
+func (l *gormLogger) Warn(ctx context.Context, msg string, data ...any) {
+	l.log(ctx, bark.WarnLevel, fmt.Sprintf(msg, data...))
+}
+
+func (l *gormLogger) Error(ctx context.Context, msg string, data ...any) {
+	l.log(ctx, bark.ErrorLevel, fmt.Sprintf(msg, data...))
+}
+
+func (l *gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.mode <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+
+	var level bark.Level
+	var msg string
+	switch {
+	case err != nil && !errors.Is(err, logger.ErrRecordNotFound):
+		level, msg = bark.ErrorLevel, "query failed"
+	case elapsed >= SlowThreshold:
+		level, msg = bark.WarnLevel, "slow query"
+	default:
+		level, msg = bark.InfoLevel, "query"
+	}
+	if !l.enabled(level) {
+		return
+	}
+
+	sql, rows := fc()
+	keyvals := []any{
+		"sql", sql,
+		"rows", rows,
+		"duration_ms", elapsed.Milliseconds(),
+		"source", utils.FileWithLineNum(),
+	}
+	if level == bark.ErrorLevel {
+		keyvals = append(keyvals, "error", err)
+	}
+
+	l.target(ctx).Log(level, msg, keyvals...)
+}
+
+// log writes msg at level if GORM's log mode allows it.
+func (l *gormLogger) log(ctx context.Context, level bark.Level, msg string) {
+	if l.enabled(level) {
+		l.target(ctx).Log(level, msg, "source", utils.FileWithLineNum())
+	}
+}
+
+// enabled reports whether GORM's log mode allows entries at level.
+func (l *gormLogger) enabled(level bark.Level) bool {
+	switch l.mode {
+	case logger.Silent:
+		return false
+	case logger.Error:
+		return level >= bark.ErrorLevel
+	case logger.Warn:
+		return level >= bark.WarnLevel
+	default:
+		return true
+	}
+}
+
+// target returns the Logger to write to for a call with ctx.
+func (l *gormLogger) target(ctx context.Context) *bark.Logger {
+	if l.logger != nil {
+		return l.logger
+	}
+	return bark.FromContext(ctx)
+}
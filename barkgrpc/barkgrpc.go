@@ -0,0 +1,61 @@
+// Package barkgrpc logs gRPC server calls through bark.
+package barkgrpc
+
+import (
+	"context"
+	"time"
+
+	"go.dalton.dog/bark"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDKey is the incoming metadata key the request ID is read from.
+const RequestIDKey = "x-request-id"
+
+// UnaryServerInterceptor returns an interceptor that logs every unary call once it
+// completes, with the fields method, duration_ms, and code, at Info level if the
+// call succeeded and at Error level otherwise.
+//
+// If the incoming metadata carries an x-request-id, it is attached as a request_id
+// field to the entry and to a child of the Logger in the call's context, which is
+// passed on to the handler so anything it logs with bark.FromContext or the *Ctx
+// functions carries the ID too.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, logger := requestLogger(ctx)
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err)
+		keyvals := []any{
+			"method", info.FullMethod,
+			"duration_ms", time.Since(start).Milliseconds(),
+			"code", code.String(),
+		}
+		if code != codes.OK {
+			logger.Error("call failed", append(keyvals, "error", err)...)
+		} else {
+			logger.Info("call", keyvals...)
+		}
+
+		return resp, err
+	}
+}
+
+// requestLogger returns the Logger for a call, with the request ID from the incoming
+// metadata attached if there is one, and ctx carrying that Logger.
+func requestLogger(ctx context.Context) (context.Context, *bark.Logger) {
+	logger := bark.FromContext(ctx)
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if ids := md.Get(RequestIDKey); len(ids) > 0 && ids[0] != "" {
+		logger = logger.With("request_id", ids[0])
+		ctx = bark.WithContext(ctx, logger)
+	}
+
+	return ctx, logger
+}
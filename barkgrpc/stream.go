@@ -0,0 +1,73 @@
+package barkgrpc
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// countingStream is a grpc.ServerStream that counts the messages sent and received,
+// and carries a context with the call's Logger.
+type countingStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	sent     atomic.Int64
+	received atomic.Int64
+}
+
+func (s *countingStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *countingStream) SendMsg(m any) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.sent.Add(1)
+	}
+	return err
+}
+
+func (s *countingStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.received.Add(1)
+	}
+	return err
+}
+
+// StreamServerInterceptor returns an interceptor that logs when each streaming call
+// opens and closes. The close entry has the fields method, duration_ms, code, sent,
+// and received, the last two counting the messages exchanged, and is logged at
+// Info level if the call succeeded and at Error level otherwise. Request IDs are
+// handled as by UnaryServerInterceptor.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, logger := requestLogger(ss.Context())
+		stream := &countingStream{ServerStream: ss, ctx: ctx}
+
+		logger.Info("stream opened", "method", info.FullMethod)
+
+		start := time.Now()
+		err := handler(srv, stream)
+
+		code := status.Code(err)
+		keyvals := []any{
+			"method", info.FullMethod,
+			"duration_ms", time.Since(start).Milliseconds(),
+			"code", code.String(),
+			"sent", stream.sent.Load(),
+			"received", stream.received.Load(),
+		}
+		if code != codes.OK {
+			logger.Error("stream failed", append(keyvals, "error", err)...)
+		} else {
+			logger.Info("stream closed", keyvals...)
+		}
+
+		return err
+	}
+}
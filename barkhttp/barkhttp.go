@@ -0,0 +1,103 @@
+// Package barkhttp logs net/http traffic through bark.
+package barkhttp
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"go.dalton.dog/bark"
+)
+
+// statusRecorder is an http.ResponseWriter that remembers the status code written.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+// Flush sends any buffered data to the client, if the underlying ResponseWriter
+// supports flushing, so streaming handlers keep working behind the middleware.
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		if r.status == 0 {
+			r.status = http.StatusOK
+		}
+		f.Flush()
+	}
+}
+
+// Hijack lets the handler take over the connection, e.g. for WebSockets,
+// if the underlying ResponseWriter supports it.
+func (r *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("barkhttp: %T doesn't support hijacking: %w", r.ResponseWriter, http.ErrNotSupported)
+	}
+	return h.Hijack()
+}
+
+// Unwrap lets http.ResponseController reach the underlying ResponseWriter.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// HTTPMiddleware logs every request handled by next once it completes, with the
+// fields method, path, status, duration_ms, and remote_addr. Responses with
+// a status of 500 or above are logged at Error level, everything else at Info.
+// If next panics, the request is logged with status 500 and the panic value
+// in a panic field, and the panic continues up the stack.
+func HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w}
+
+		defer func() {
+			if v := recover(); v != nil {
+				logRequest(r, http.StatusInternalServerError, start, "panic", v)
+				panic(v)
+			}
+		}()
+
+		next.ServeHTTP(recorder, r)
+
+		status := recorder.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		logRequest(r, status, start)
+	})
+}
+
+// logRequest logs r, answered with status, with any extra fields after the usual ones.
+func logRequest(r *http.Request, status int, start time.Time, extra ...any) {
+	keyvals := []any{
+		"method", r.Method,
+		"path", r.URL.Path,
+		"status", status,
+		"duration_ms", time.Since(start).Milliseconds(),
+		"remote_addr", r.RemoteAddr,
+	}
+	keyvals = append(keyvals, extra...)
+
+	if status >= http.StatusInternalServerError {
+		bark.Error("request failed", keyvals...)
+	} else {
+		bark.Info("request", keyvals...)
+	}
+}
@@ -0,0 +1,40 @@
+package barkhttp
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"go.dalton.dog/bark"
+)
+
+// LevelHandlerOptions configures the handler returned by LevelHandler.
+// Zero values use the defaults.
+type LevelHandlerOptions struct {
+	// Token, if set, must be sent as a bearer token, "Authorization: Bearer <token>",
+	// with every request. By default the handler is unprotected.
+	Token string
+}
+
+// LevelHandler returns bark.LevelHandler, answering GET with the current global
+// level and changing it on PUT or POST with a body such as {"level":"debug"},
+// guarded by the bearer token in opts, if any. Requests without the token are
+// answered with 401 Unauthorized.
+//
+//	mux.Handle("/log-level", barkhttp.LevelHandler(barkhttp.LevelHandlerOptions{Token: os.Getenv("LOG_LEVEL_TOKEN")}))
+func LevelHandler(opts LevelHandlerOptions) http.Handler {
+	handler := bark.LevelHandler()
+	if opts.Token == "" {
+		return handler
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(opts.Token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
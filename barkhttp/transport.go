@@ -0,0 +1,91 @@
+package barkhttp
+
+import (
+	"net/http"
+	"time"
+
+	"go.dalton.dog/bark"
+)
+
+// sensitiveHeaders are redacted from logged requests unless WithSensitiveHeaders is used.
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie"}
+
+// redacted replaces the values of sensitive headers.
+const redacted = "[REDACTED]"
+
+// TransportOption configures a LoggingTransport.
+type TransportOption func(t *loggingTransport)
+
+// WithLevel sets the level requests and responses are logged at. The default is Info.
+// Failed requests are always logged at Error level.
+func WithLevel(level bark.Level) TransportOption {
+	return func(t *loggingTransport) {
+		t.level = level
+	}
+}
+
+// WithSensitiveHeaders logs the Authorization, Proxy-Authorization, and Cookie
+// headers as they are instead of redacting them. Only use it while debugging.
+func WithSensitiveHeaders() TransportOption {
+	return func(t *loggingTransport) {
+		t.logSensitive = true
+	}
+}
+
+// loggingTransport is the http.RoundTripper returned by LoggingTransport.
+type loggingTransport struct {
+	inner        http.RoundTripper
+	level        bark.Level
+	logSensitive bool
+}
+
+// LoggingTransport wraps inner, or http.DefaultTransport if it is nil, to log every
+// outbound request before it is sent, with its method, URL, and headers, and its
+// response after, with the status and latency_ms. Requests that fail are logged
+// at Error level with the error. Sensitive headers are redacted by default:
+//
+//	client := &http.Client{Transport: barkhttp.LoggingTransport(nil, barkhttp.WithLevel(bark.DebugLevel))}
+func LoggingTransport(inner http.RoundTripper, opts ...TransportOption) http.RoundTripper {
+	if inner == nil {
+		inner = http.DefaultTransport
+	}
+
+	t := &loggingTransport{inner: inner, level: bark.InfoLevel}
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	method, url := req.Method, req.URL.Redacted()
+	bark.Log(t.level, "sending request", "method", method, "url", url, "headers", t.headers(req.Header))
+
+	start := time.Now()
+	resp, err := t.inner.RoundTrip(req)
+	latency := time.Since(start).Milliseconds()
+
+	if err != nil {
+		bark.Error("request failed", "method", method, "url", url, "latency_ms", latency, "error", err)
+		return resp, err
+	}
+
+	bark.Log(t.level, "received response", "method", method, "url", url, "status", resp.StatusCode, "latency_ms", latency)
+	return resp, nil
+}
+
+// headers returns a copy of h with sensitive headers redacted, unless t logs them.
+func (t *loggingTransport) headers(h http.Header) http.Header {
+	if t.logSensitive {
+		return h
+	}
+
+	h = h.Clone()
+	for _, name := range sensitiveHeaders {
+		if _, ok := h[name]; ok {
+			h[name] = []string{redacted}
+		}
+	}
+	return h
+}
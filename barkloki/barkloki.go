@@ -0,0 +1,159 @@
+// Package barkloki ships bark entries to Grafana Loki over its HTTP push API.
+package barkloki
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"go.dalton.dog/bark/internal/batch"
+)
+
+// PushPath is the path of Loki's push endpoint, appended to the URL given to NewLokiSink.
+const PushPath = "/loki/api/v1/push"
+
+// LokiOptions configures a Loki sink. Zero values use the defaults.
+type LokiOptions struct {
+	// BatchSize is the number of lines sent in one push. The default is 100.
+	BatchSize int
+	// FlushInterval is the longest a line waits before being pushed. The default is one second.
+	FlushInterval time.Duration
+	// Timeout limits each push request. The default is ten seconds.
+	Timeout time.Duration
+	// TLSConfig configures HTTPS connections to Loki, e.g. for client certificates.
+	TLSConfig *tls.Config
+	// TenantID is sent as the X-Scope-OrgID header for multi-tenant Loki.
+	TenantID string
+}
+
+// lokiSink is the io.WriteCloser returned by NewLokiSink.
+type lokiSink struct {
+	endpoint string
+	labels   map[string]string
+	opts     LokiOptions
+	client   *http.Client
+
+	batch *batch.Batcher[[2]string]
+}
+
+// NewLokiSink returns a writer that pushes every line written to it to the Loki
+// server at baseURL, e.g. "http://localhost:3100", as a stream with the given labels.
+// Lines are batched, and pushed when a batch fills up or the flush interval passes.
+// Pass it to bark.AddWriterLogger, usually with a structured format:
+//
+//	sink, err := barkloki.NewLokiSink("http://loki:3100", map[string]string{"app": "api"}, barkloki.LokiOptions{})
+//	...
+//	bark.AddWriterLogger(sink, bark.WithFormat(bark.FormatLogfmt))
+//
+// The sink implements Flush, so bark.Flush pushes pending lines, and Close, called
+// by bark.Shutdown, pushes them and stops. Push failures are reported by the
+// next Flush or Close rather than by Write, so logging never blocks on Loki.
+func NewLokiSink(baseURL string, labels map[string]string, opts LokiOptions) (io.WriteCloser, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("barkloki: invalid URL: %v", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("barkloki: URL %q must use http or https", baseURL)
+	}
+	if len(labels) == 0 {
+		return nil, errors.New("barkloki: Loki requires at least one label")
+	}
+
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 100
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = time.Second
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = opts.TLSConfig
+
+	s := &lokiSink{
+		endpoint: u.JoinPath(PushPath).String(),
+		labels:   maps.Clone(labels),
+		opts:     opts,
+		client:   &http.Client{Transport: transport, Timeout: opts.Timeout},
+	}
+	s.batch = batch.New(opts.BatchSize, opts.FlushInterval, s.send)
+
+	return s, nil
+}
+
+// Write queues p as one line, stamped with the current time.
+func (s *lokiSink) Write(p []byte) (int, error) {
+	line := string(bytes.TrimRight(p, "\n"))
+	ts := strconv.FormatInt(time.Now().UnixNano(), 10)
+
+	if err := s.batch.Add([2]string{ts, line}); err != nil {
+		return 0, errors.New("barkloki: write to closed sink")
+	}
+	return len(p), nil
+}
+
+// Flush pushes every pending line and returns any error from pushes since the last Flush.
+func (s *lokiSink) Flush() error {
+	return s.batch.Flush()
+}
+
+// Close pushes every pending line and stops the sink.
+func (s *lokiSink) Close() error {
+	return s.batch.Close()
+}
+
+// pushRequest is the body of a request to Loki's push endpoint.
+type pushRequest struct {
+	Streams []stream `json:"streams"`
+}
+
+type stream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// send pushes one batch of lines to Loki.
+func (s *lokiSink) send(values [][2]string) error {
+	body, err := json.Marshal(pushRequest{Streams: []stream{{Stream: s.labels, Values: values}}})
+	if err != nil {
+		return fmt.Errorf("barkloki: encoding push: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.opts.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("barkloki: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.opts.TenantID != "" {
+		req.Header.Set("X-Scope-OrgID", s.opts.TenantID)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("barkloki: push failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("barkloki: push failed: %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+
+	io.Copy(io.Discard, resp.Body)
+	return nil
+}
@@ -0,0 +1,154 @@
+package barkloki_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"go.dalton.dog/bark/barkloki"
+)
+
+// lokiServer records the batches pushed to it.
+type lokiServer struct {
+	mu       sync.Mutex
+	batches  [][]string
+	labels   []map[string]string
+	status   int
+	requests int
+}
+
+func (l *lokiServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Streams []struct {
+			Stream map[string]string `json:"stream"`
+			Values [][2]string       `json:"values"`
+		} `json:"streams"`
+	}
+	if r.URL.Path != barkloki.PushPath || json.NewDecoder(r.Body).Decode(&body) != nil || len(body.Streams) != 1 {
+		http.Error(w, "bad push", http.StatusBadRequest)
+		return
+	}
+
+	// Hold up the first push, so any pushed alongside it would overtake it.
+	l.mu.Lock()
+	first := l.requests == 0
+	l.requests++
+	l.mu.Unlock()
+	if first {
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var lines []string
+	for _, v := range body.Streams[0].Values {
+		lines = append(lines, v[1])
+	}
+	l.batches = append(l.batches, lines)
+	l.labels = append(l.labels, body.Streams[0].Stream)
+
+	if l.status != 0 {
+		w.WriteHeader(l.status)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (l *lokiServer) Batches() [][]string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return slices.Clone(l.batches)
+}
+
+func newSink(t *testing.T, server *lokiServer, labels map[string]string) io.WriteCloser {
+	t.Helper()
+
+	srv := httptest.NewServer(server)
+	t.Cleanup(srv.Close)
+
+	sink, err := barkloki.NewLokiSink(srv.URL, labels, barkloki.LokiOptions{BatchSize: 2, FlushInterval: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sink.Close() })
+	return sink
+}
+
+func TestLokiSinkBatchesInOrderAndFlushWaits(t *testing.T) {
+	server := &lokiServer{}
+	labels := map[string]string{"app": "api"}
+	sink := newSink(t, server, labels)
+
+	// Changing the caller's map afterwards doesn't change the stream's labels.
+	labels["app"] = "changed"
+
+	write := func(lines ...string) {
+		for _, line := range lines {
+			if _, err := io.WriteString(sink, line+"\n"); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	// The first batch fills up and starts being pushed in the background.
+	write("one", "two")
+	time.Sleep(10 * time.Millisecond)
+	write("three", "four", "five")
+	if err := sink.(interface{ Flush() error }).Flush(); err != nil {
+		t.Fatalf("Flush() = %v", err)
+	}
+
+	// Every batch, including any the full batches started, is in by the time Flush returns.
+	want := [][]string{{"one", "two"}, {"three", "four"}, {"five"}}
+	if got := server.Batches(); !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("batches = %q, want %q", got, want)
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	for _, got := range server.labels {
+		if got["app"] != "api" || len(got) != 1 {
+			t.Errorf("stream labels = %v, want app=api", got)
+		}
+	}
+}
+
+func TestLokiSinkFlushReportsPushErrors(t *testing.T) {
+	server := &lokiServer{status: http.StatusInternalServerError}
+	sink := newSink(t, server, map[string]string{"app": "api"})
+
+	io.WriteString(sink, "lost\n")
+	flusher := sink.(interface{ Flush() error })
+	if err := flusher.Flush(); err == nil {
+		t.Error("Flush() = nil after a failed push, want an error")
+	}
+	if err := flusher.Flush(); err != nil {
+		t.Errorf("second Flush() = %v, want the error reported only once", err)
+	}
+}
+
+func TestLokiSinkClosePushesPending(t *testing.T) {
+	server := &lokiServer{}
+	sink := newSink(t, server, map[string]string{"app": "api"})
+
+	io.WriteString(sink, "last words\n")
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close() = %v", err)
+	}
+	if got, want := server.Batches(), [][]string{{"last words"}}; !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("batches = %q, want %q", got, want)
+	}
+
+	if _, err := io.WriteString(sink, "too late\n"); err == nil {
+		t.Error("Write after Close succeeded, want an error")
+	}
+	if err := sink.Close(); err != nil {
+		t.Errorf("second Close() = %v, want nil", err)
+	}
+}
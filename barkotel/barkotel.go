@@ -0,0 +1,154 @@
+// Package barkotel bridges bark entries into OpenTelemetry logs.
+package barkotel
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.dalton.dog/bark"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope the records are emitted under.
+const ScopeName = "go.dalton.dog/bark"
+
+// NewOTelHook returns a PostHook that converts every bark entry into an OpenTelemetry
+// log record, with the message as body and the fields as attributes, and passes it
+// to exporter through a simple processor of the OpenTelemetry SDK. Fields named
+// trace_id and span_id, holding hex IDs, become the trace and span of the record:
+//
+//	exporter, err := otlploghttp.New(ctx)
+//	...
+//	bark.AddPostHook(barkotel.NewOTelHook(exporter))
+//
+// Exporting synchronously can be slower than the post hook timeout; entries still
+// reach the exporter, but in the background. Wrap a slow exporter in a batching
+// one, or flush and shut it down before exiting.
+func NewOTelHook(exporter sdklog.Exporter) bark.PostHook {
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	logger := provider.Logger(ScopeName)
+
+	return func(level bark.Level, msg string, keyvals []any) {
+		ctx := context.Background()
+		if sc, ok := spanContext(keyvals); ok {
+			ctx = trace.ContextWithSpanContext(ctx, sc)
+			keyvals = withoutTraceFields(keyvals)
+		}
+		logger.Emit(ctx, record(level, msg, keyvals))
+	}
+}
+
+// spanContext returns the span context named by the trace_id and span_id
+// fields in keyvals, reporting false unless both are present and valid.
+func spanContext(keyvals []any) (trace.SpanContext, bool) {
+	var cfg trace.SpanContextConfig
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		id, ok := keyvals[i+1].(string)
+		if !ok {
+			continue
+		}
+		switch fmt.Sprint(keyvals[i]) {
+		case "trace_id":
+			cfg.TraceID, _ = trace.TraceIDFromHex(id)
+		case "span_id":
+			cfg.SpanID, _ = trace.SpanIDFromHex(id)
+		}
+	}
+
+	sc := trace.NewSpanContext(cfg)
+	return sc, sc.IsValid()
+}
+
+// withoutTraceFields returns keyvals without its trace_id and span_id fields.
+func withoutTraceFields(keyvals []any) []any {
+	kept := make([]any, 0, len(keyvals))
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if key := fmt.Sprint(keyvals[i]); key != "trace_id" && key != "span_id" {
+			kept = append(kept, keyvals[i], keyvals[i+1])
+		}
+	}
+	return kept
+}
+
+// record converts a bark entry into an OpenTelemetry log record.
+func record(level bark.Level, msg string, keyvals []any) otellog.Record {
+	var r otellog.Record
+	now := time.Now()
+	r.SetTimestamp(now)
+	r.SetObservedTimestamp(now)
+	r.SetBody(otellog.StringValue(msg))
+	r.SetSeverity(severity(level))
+	if text := bark.LevelName(level); text != "" {
+		r.SetSeverityText(text)
+	}
+
+	attrs := make([]otellog.KeyValue, 0, len(keyvals)/2)
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		attrs = append(attrs, otellog.KeyValue{Key: fmt.Sprint(keyvals[i]), Value: value(keyvals[i+1])})
+	}
+	r.AddAttributes(attrs...)
+
+	return r
+}
+
+// severity maps a bark level onto the OpenTelemetry severity numbers,
+// placing levels between bark's built-in ones in the nearest range below.
+func severity(level bark.Level) otellog.Severity {
+	switch {
+	case level >= bark.FatalLevel:
+		return otellog.SeverityFatal
+	case level >= bark.PanicLevel:
+		return otellog.SeverityError4
+	case level >= bark.ErrorLevel:
+		return otellog.SeverityError
+	case level >= bark.WarnLevel:
+		return otellog.SeverityWarn
+	case level >= bark.NoticeLevel:
+		return otellog.SeverityInfo2
+	case level >= bark.InfoLevel:
+		return otellog.SeverityInfo
+	case level >= bark.DebugLevel:
+		return otellog.SeverityDebug
+	default:
+		return otellog.SeverityTrace
+	}
+}
+
+// value converts a field value into an OpenTelemetry value.
+func value(v any) otellog.Value {
+	switch v := v.(type) {
+	case string:
+		return otellog.StringValue(v)
+	case bool:
+		return otellog.BoolValue(v)
+	case int:
+		return otellog.IntValue(v)
+	case int64:
+		return otellog.Int64Value(v)
+	case int32:
+		return otellog.Int64Value(int64(v))
+	case uint32:
+		return otellog.Int64Value(int64(v))
+	case float64:
+		return otellog.Float64Value(v)
+	case float32:
+		return otellog.Float64Value(float64(v))
+	case []byte:
+		return otellog.BytesValue(v)
+	case time.Duration:
+		return otellog.StringValue(v.String())
+	case time.Time:
+		return otellog.StringValue(v.Format(time.RFC3339Nano))
+	case error:
+		return otellog.StringValue(v.Error())
+	case fmt.Stringer:
+		return otellog.StringValue(v.String())
+	case nil:
+		return otellog.Value{}
+	default:
+		return otellog.StringValue(fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,88 @@
+package barkotel_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"go.dalton.dog/bark"
+	"go.dalton.dog/bark/barkotel"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// memoryExporter keeps the log records exported to it.
+type memoryExporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (e *memoryExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+	return nil
+}
+
+func (e *memoryExporter) Shutdown(context.Context) error   { return nil }
+func (e *memoryExporter) ForceFlush(context.Context) error { return nil }
+
+func (e *memoryExporter) Records() []sdklog.Record {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]sdklog.Record(nil), e.records...)
+}
+
+func TestOTelHookLinksRecordsToSpans(t *testing.T) {
+	spans := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(spans))
+	defer provider.Shutdown(context.Background())
+
+	logs := &memoryExporter{}
+	hook := barkotel.NewOTelHook(logs)
+
+	_, span := provider.Tracer("test").Start(context.Background(), "checkout")
+	sc := span.SpanContext()
+	hook(bark.WarnLevel, "payment retried", []any{
+		"trace_id", sc.TraceID().String(),
+		"span_id", sc.SpanID().String(),
+		"attempt", 2,
+	})
+	span.End()
+
+	ended := spans.GetSpans()
+	if len(ended) != 1 {
+		t.Fatalf("exported %d spans, want 1", len(ended))
+	}
+	records := logs.Records()
+	if len(records) != 1 {
+		t.Fatalf("exported %d log records, want 1", len(records))
+	}
+	r := records[0]
+
+	if r.TraceID() != ended[0].SpanContext.TraceID() {
+		t.Errorf("record trace ID = %s, want %s", r.TraceID(), ended[0].SpanContext.TraceID())
+	}
+	if r.SpanID() != ended[0].SpanContext.SpanID() {
+		t.Errorf("record span ID = %s, want %s", r.SpanID(), ended[0].SpanContext.SpanID())
+	}
+	if got := r.Body().AsString(); got != "payment retried" {
+		t.Errorf("record body = %q, want %q", got, "payment retried")
+	}
+	if r.Severity() != otellog.SeverityWarn || r.SeverityText() != "warn" {
+		t.Errorf("record severity = %v %q, want %v %q", r.Severity(), r.SeverityText(), otellog.SeverityWarn, "warn")
+	}
+
+	attrs := map[string]otellog.Value{}
+	r.WalkAttributes(func(kv otellog.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+		return true
+	})
+	if len(attrs) != 1 || attrs["attempt"].AsInt64() != 2 {
+		t.Errorf("record attributes = %v, want only attempt=2", attrs)
+	}
+}
@@ -0,0 +1,46 @@
+// Package barkprom publishes counts of bark entries as Prometheus metrics.
+package barkprom
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.dalton.dog/bark"
+)
+
+// RegisterPrometheusMetrics registers bark_log_entries_total, a counter of logged
+// entries with a level label, with reg, and adds a bark post hook that increments it.
+// Entries hidden by the level or a filter aren't counted.
+//
+//	if _, err := barkprom.RegisterPrometheusMetrics(prometheus.DefaultRegisterer); err != nil {
+//		...
+//	}
+//
+// Passing the returned Collector to reg.Unregister stops publishing the counter.
+// The hook stays in place until bark.RemoveAllHooks or bark.Reset, counting into
+// the unregistered vector.
+func RegisterPrometheusMetrics(reg prometheus.Registerer) (prometheus.Collector, error) {
+	entries := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "bark_log_entries_total",
+		Help: "Number of log entries written by bark, by level.",
+	}, []string{"level"})
+
+	if err := reg.Register(entries); err != nil {
+		return nil, err
+	}
+
+	bark.AddPostHook(func(level bark.Level, _ string, _ []any) {
+		entries.WithLabelValues(levelLabel(level)).Inc()
+	})
+
+	return entries, nil
+}
+
+// levelLabel returns the value of the level label for level: its name,
+// or its number if it has none.
+func levelLabel(level bark.Level) string {
+	if name := bark.LevelName(level); name != "" {
+		return name
+	}
+	return strconv.Itoa(int(level))
+}
@@ -0,0 +1,228 @@
+// Package barksplunk ships bark entries to Splunk over its HTTP Event Collector (HEC).
+package barksplunk
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.dalton.dog/bark"
+	"go.dalton.dog/bark/internal/batch"
+)
+
+// EventPath is the path of the HEC event endpoint, appended to the URL given to NewSplunkSink.
+const EventPath = "/services/collector/event"
+
+// SplunkOptions configures a Splunk sink. Zero values use the defaults.
+type SplunkOptions struct {
+	// Host, Source, SourceType, and Index are sent with every event.
+	// Empty ones are left for Splunk to fill in from the token's settings.
+	Host       string
+	Source     string
+	SourceType string
+	Index      string
+
+	// BatchSize is the number of events sent in one request. The default is 100.
+	BatchSize int
+	// FlushInterval is the longest an event waits before being sent. The default is one second.
+	FlushInterval time.Duration
+	// Timeout limits each request. The default is ten seconds.
+	Timeout time.Duration
+	// TLSConfig configures HTTPS connections to Splunk, e.g. to trust a private CA.
+	TLSConfig *tls.Config
+}
+
+// splunkSink is the io.WriteCloser returned by NewSplunkSink.
+type splunkSink struct {
+	endpoint string
+	token    string
+	opts     SplunkOptions
+	client   *http.Client
+
+	batch *batch.Batcher[event]
+}
+
+// NewSplunkSink returns a writer that sends every line written to it as an event
+// to the HEC at baseURL, e.g. "https://splunk:8088", authenticating with token.
+// Events are batched, and sent when a batch fills up or the flush interval passes.
+// Pass it to bark.AddWriterLogger, preferably with FormatJSON, so that Splunk
+// receives each entry's fields as a JSON event:
+//
+//	sink, err := barksplunk.NewSplunkSink("https://splunk:8088", token, barksplunk.SplunkOptions{SourceType: "_json"})
+//	...
+//	bark.AddWriterLogger(sink, bark.WithFormat(bark.FormatJSON))
+//
+// The level of each entry, read from its JSON or logfmt "level" field, is sent as
+// a "severity" indexed field. Other lines are sent as plain text events.
+//
+// The sink implements Flush, so bark.Flush sends pending events, and Close, called
+// by bark.Shutdown, sends them and stops. Failures are reported by the
+// next Flush or Close rather than by Write, so logging never blocks on Splunk.
+func NewSplunkSink(baseURL, token string, opts SplunkOptions) (io.WriteCloser, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("barksplunk: invalid URL: %v", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("barksplunk: URL %q must use http or https", baseURL)
+	}
+	if token == "" {
+		return nil, errors.New("barksplunk: HEC token is empty")
+	}
+
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 100
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = time.Second
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = opts.TLSConfig
+
+	s := &splunkSink{
+		endpoint: u.JoinPath(EventPath).String(),
+		token:    token,
+		opts:     opts,
+		client:   &http.Client{Transport: transport, Timeout: opts.Timeout},
+	}
+	s.batch = batch.New(opts.BatchSize, opts.FlushInterval, s.send)
+
+	return s, nil
+}
+
+// event is one event in a request to the HEC event endpoint.
+type event struct {
+	Time       float64           `json:"time"`
+	Host       string            `json:"host,omitempty"`
+	Source     string            `json:"source,omitempty"`
+	SourceType string            `json:"sourcetype,omitempty"`
+	Index      string            `json:"index,omitempty"`
+	Event      any               `json:"event"`
+	Fields     map[string]string `json:"fields,omitempty"`
+}
+
+// Write queues p as one event, stamped with the current time.
+func (s *splunkSink) Write(p []byte) (int, error) {
+	line := bytes.TrimRight(p, "\n")
+
+	e := event{
+		Time:       float64(time.Now().UnixMicro()) / 1e6,
+		Host:       s.opts.Host,
+		Source:     s.opts.Source,
+		SourceType: s.opts.SourceType,
+		Index:      s.opts.Index,
+	}
+
+	var level string
+	var fields map[string]any
+	if json.Unmarshal(line, &fields) == nil {
+		e.Event = json.RawMessage(bytes.Clone(line))
+		level, _ = fields["level"].(string)
+	} else {
+		e.Event = string(line)
+		level = logfmtLevel(string(line))
+	}
+	if level != "" {
+		e.Fields = map[string]string{"severity": severity(level)}
+	}
+
+	if err := s.batch.Add(e); err != nil {
+		return 0, errors.New("barksplunk: write to closed sink")
+	}
+	return len(p), nil
+}
+
+// logfmtLevel returns the value of the level field of a logfmt line, if any.
+func logfmtLevel(line string) string {
+	for _, field := range strings.Fields(line) {
+		if level, ok := strings.CutPrefix(field, "level="); ok {
+			return strings.Trim(level, `"`)
+		}
+	}
+	return ""
+}
+
+// severity maps the name of a bark level to a Splunk severity. Levels added
+// with bark.RegisterLevel take the severity of the nearest built-in level below them,
+// and unknown names are passed on as they are.
+func severity(name string) string {
+	level, err := bark.ParseLevel(name)
+	if err != nil {
+		return name
+	}
+
+	switch {
+	case level == bark.PrintLevel:
+		return "info"
+	case level >= bark.PanicLevel:
+		return "critical"
+	case level >= bark.ErrorLevel:
+		return "error"
+	case level >= bark.WarnLevel:
+		return "warning"
+	case level >= bark.NoticeLevel:
+		return "notice"
+	case level >= bark.InfoLevel:
+		return "info"
+	default:
+		return "debug"
+	}
+}
+
+// Flush sends every pending event and returns any error from sends since the last Flush.
+func (s *splunkSink) Flush() error {
+	return s.batch.Flush()
+}
+
+// Close sends every pending event and stops the sink.
+func (s *splunkSink) Close() error {
+	return s.batch.Close()
+}
+
+// send posts one batch of events to the HEC. The HEC takes a batch as
+// concatenated JSON objects rather than an array.
+func (s *splunkSink) send(events []event) error {
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, e := range events {
+		if err := enc.Encode(e); err != nil {
+			return fmt.Errorf("barksplunk: encoding event: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.opts.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, &body)
+	if err != nil {
+		return fmt.Errorf("barksplunk: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Splunk "+s.token)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("barksplunk: send failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("barksplunk: send failed: %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+
+	io.Copy(io.Discard, resp.Body)
+	return nil
+}
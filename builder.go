@@ -0,0 +1,64 @@
+package bark
+
+// LogBuilder composes an entry step by step, for callers that assemble entries
+// programmatically:
+//
+//	bark.Entry().Level(bark.WarnLevel).Msg("disk almost full").Field("free_mb", free).Send()
+//
+// Nothing is logged until Send, so a builder can be dropped at any point.
+// A LogBuilder is not safe for concurrent use.
+type LogBuilder struct {
+	logger  *Logger
+	level   Level
+	msg     string
+	keyvals []any
+}
+
+// Entry starts building an entry for the default Logger, at Info level unless set with Level.
+func Entry() *LogBuilder {
+	return std.Entry()
+}
+
+// Entry starts building an entry for l, at Info level unless set with Level.
+func (l *Logger) Entry() *LogBuilder {
+	return &LogBuilder{logger: l, level: InfoLevel}
+}
+
+// Level sets the level of the entry, which may be one added with RegisterLevel.
+func (b *LogBuilder) Level(level Level) *LogBuilder {
+	b.level = level
+	return b
+}
+
+// Msg sets the message of the entry.
+func (b *LogBuilder) Msg(msg string) *LogBuilder {
+	b.msg = msg
+	return b
+}
+
+// Field adds a field to the entry.
+func (b *LogBuilder) Field(key, value any) *LogBuilder {
+	b.keyvals = append(b.keyvals, key, value)
+	return b
+}
+
+// Fields adds fields to the entry, given as alternating keys and values.
+func (b *LogBuilder) Fields(keyvals ...any) *LogBuilder {
+	b.keyvals = append(b.keyvals, keyvals...)
+	return b
+}
+
+// Err adds err to the entry as an "error" field, unless it is nil.
+func (b *LogBuilder) Err(err error) *LogBuilder {
+	if err != nil {
+		b.keyvals = append(b.keyvals, "error", err)
+	}
+	return b
+}
+
+// Send logs the entry as built so far, subject to level filtering like any other.
+// Like Log, it doesn't exit or panic at FatalLevel or PanicLevel.
+// The builder can be changed and sent again afterwards.
+func (b *LogBuilder) Send() {
+	b.logger.log(b.level, b.msg, b.keyvals...)
+}
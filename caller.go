@@ -0,0 +1,78 @@
+package bark
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// packagePath is bark's import path, used to recognize its own stack frames.
+const packagePath = "go.dalton.dog/bark"
+
+// helpers holds the names of functions marked with Helper.
+var helpers sync.Map
+
+// Helper marks the calling function as a logging helper, so that reported
+// caller locations skip it and point at the code that called it instead.
+// It is the equivalent of testing.TB.Helper, for wrappers around bark:
+//
+//	func logStep(msg string) {
+//		bark.Helper()
+//		bark.Info("step: " + msg)
+//	}
+//
+// Helpers are recorded by function name and apply to every Logger and to the
+// package-level functions alike. Bark's own functions are always skipped.
+func Helper() {
+	markHelper()
+}
+
+// Helper marks the calling function as a logging helper. See the package-level Helper.
+func (l *Logger) Helper() {
+	markHelper()
+}
+
+// markHelper records the function that called Helper.
+func markHelper() {
+	var pcs [1]uintptr
+	// Skip runtime.Callers, markHelper, and Helper.
+	if runtime.Callers(3, pcs[:]) == 0 {
+		return
+	}
+
+	frame, _ := runtime.CallersFrames(pcs[:]).Next()
+	helpers.Store(frame.Function, struct{}{})
+}
+
+// isSkippedFrame reports whether fn, a fully qualified function name,
+// belongs to bark itself or was marked with Helper.
+func isSkippedFrame(fn string) bool {
+	if strings.HasPrefix(fn, packagePath+".") {
+		return true
+	}
+	_, helper := helpers.Load(fn)
+	return helper
+}
+
+// callerOffset returns the caller offset that makes an underlying logger,
+// invoked directly from the function calling callerOffset, report the first
+// frame outside bark and any helpers.
+func callerOffset() int {
+	const maxDepth = 64
+	var pcs [maxDepth]uintptr
+	// Skip runtime.Callers and callerOffset, so depth 0 is our caller.
+	n := runtime.Callers(2, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+
+	depth := 0
+	for {
+		frame, more := frames.Next()
+		if !isSkippedFrame(frame.Function) || !more {
+			break
+		}
+		depth++
+	}
+
+	// The underlying logger already skips its direct caller.
+	return depth - 1
+}
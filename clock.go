@@ -0,0 +1,69 @@
+package bark
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Clock supplies the time stamped on log entries.
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the default Clock, reading the time with time.Now.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// fakeClock is a Clock stopped at a single instant.
+type fakeClock struct {
+	t time.Time
+}
+
+func (c fakeClock) Now() time.Time {
+	return c.t
+}
+
+// NewFakeClock returns a Clock that always reports t, for use with InjectClock.
+func NewFakeClock(t time.Time) Clock {
+	return fakeClock{t: t}
+}
+
+// clockHolder wraps a Clock so implementations of different types
+// can be stored in the same atomic.Value.
+type clockHolder struct {
+	Clock
+}
+
+var clock atomic.Value // of clockHolder
+
+// InjectClock makes every sink, including existing ones, stamp entries with
+// the time reported by c. Tests can use it with NewFakeClock to make
+// timestamps, and so whole lines of output, deterministic:
+//
+//	bark.InjectClock(bark.NewFakeClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)))
+//	defer bark.InjectClock(nil)
+//
+// A nil c restores the system clock, as does Reset.
+func InjectClock(c Clock) {
+	if c == nil {
+		c = systemClock{}
+	}
+	clock.Store(clockHolder{c})
+}
+
+// now returns the current time according to the injected Clock.
+func now() time.Time {
+	if holder, ok := clock.Load().(clockHolder); ok {
+		return holder.Now()
+	}
+	return time.Now()
+}
+
+// clockTime adapts now to the underlying logger's time function,
+// ignoring the time it passes in.
+func clockTime(time.Time) time.Time {
+	return now()
+}
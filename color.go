@@ -0,0 +1,265 @@
+package bark
+
+import (
+	"io"
+	"math"
+	"os"
+	"strconv"
+
+	"github.com/muesli/termenv"
+)
+
+// ColorMode controls whether pretty sinks style their output with colors.
+type ColorMode int
+
+const (
+	// ColorAuto colors output written to a terminal that supports it,
+	// unless the environment says otherwise. It is the default.
+	ColorAuto ColorMode = iota
+	// ColorAlways colors output even when it isn't written to a terminal.
+	ColorAlways
+	// ColorNever never colors output.
+	ColorNever
+)
+
+// String returns the name of the color mode.
+func (m ColorMode) String() string {
+	switch m {
+	case ColorAuto:
+		return "auto"
+	case ColorAlways:
+		return "always"
+	case ColorNever:
+		return "never"
+	default:
+		return "unknown"
+	}
+}
+
+// ColorProfile names how many colors a sink can render.
+type ColorProfile int
+
+const (
+	// ProfileAuto detects the profile from the writer and environment. It is the default.
+	ProfileAuto ColorProfile = iota
+	// ProfileTrueColor renders hex colors exactly.
+	ProfileTrueColor
+	// ProfileANSI256 maps colors to the nearest of the 256-color palette.
+	ProfileANSI256
+	// ProfileANSI16 maps colors to the 16 basic ANSI colors, by hue.
+	ProfileANSI16
+	// ProfileASCII renders no colors.
+	ProfileASCII
+)
+
+// String returns the name of the color profile.
+func (p ColorProfile) String() string {
+	switch p {
+	case ProfileAuto:
+		return "auto"
+	case ProfileTrueColor:
+		return "truecolor"
+	case ProfileANSI256:
+		return "ansi256"
+	case ProfileANSI16:
+		return "ansi16"
+	case ProfileASCII:
+		return "ascii"
+	default:
+		return "unknown"
+	}
+}
+
+// termenv returns the termenv profile for p, which must not be ProfileAuto.
+func (p ColorProfile) termenv() termenv.Profile {
+	switch p {
+	case ProfileTrueColor:
+		return termenv.TrueColor
+	case ProfileANSI256:
+		return termenv.ANSI256
+	case ProfileANSI16:
+		return termenv.ANSI
+	default:
+		return termenv.Ascii
+	}
+}
+
+// envColorMode reads the color conventions from the environment, in order of
+// precedence: a non-empty NO_COLOR disables colors, as does CLICOLOR=0, while
+// CLICOLOR_FORCE set to anything but 0 forces them on.
+func envColorMode() ColorMode {
+	if os.Getenv("NO_COLOR") != "" {
+		return ColorNever
+	}
+	if os.Getenv("CLICOLOR") == "0" {
+		return ColorNever
+	}
+	if forced := os.Getenv("CLICOLOR_FORCE"); forced != "" && forced != "0" {
+		return ColorAlways
+	}
+	return ColorAuto
+}
+
+// colorProfile returns the color profile a sink writing to w renders with.
+// An explicit mode wins over the environment, which wins over detecting
+// what w supports: writers that aren't terminals, such as files, pipes,
+// and buffers, get the plain Ascii profile, so no escape sequences are written.
+// A forced profile replaces the detected one, unless colors are off.
+func colorProfile(w io.Writer, mode ColorMode, force ColorProfile) termenv.Profile {
+	if mode == ColorAuto {
+		mode = envColorMode()
+	}
+	if mode == ColorNever {
+		return termenv.Ascii
+	}
+	if force != ProfileAuto {
+		return force.termenv()
+	}
+
+	detected := termenv.NewOutput(w).ColorProfile()
+	if mode == ColorAlways && detected == termenv.Ascii {
+		return termenv.ANSI
+	}
+	return detected
+}
+
+// profileColor returns color, a hex string or ANSI index, as a color lipgloss
+// renders as is under profile: color itself for truecolor, or the index of the
+// palette color chosen for it otherwise. Choosing here, rather than leaving it to
+// the renderer, keeps the basic colors true to each hue, so warnings stay yellow
+// and errors red on 16-color terminals.
+func profileColor(color string, profile termenv.Profile) string {
+	if i, ok := ansiIndex(color); ok && (i < 16 || profile != termenv.ANSI) {
+		return color
+	}
+
+	r, g, b, err := colorRGB(color)
+	if err != nil {
+		return color
+	}
+
+	switch profile {
+	case termenv.ANSI256:
+		return strconv.Itoa(ansi256(r, g, b))
+	case termenv.ANSI:
+		return strconv.Itoa(ansi16(r, g, b))
+	default:
+		return color
+	}
+}
+
+// cubeLevels are the channel values of the 6×6×6 color cube in the 256-color palette.
+var cubeLevels = [6]int{0, 0x5f, 0x87, 0xaf, 0xd7, 0xff}
+
+// ansi256 returns the index of the color nearest to r, g, b among the color cube
+// (16-231) and the gray ramp (232-255) of the 256-color palette. The first 16
+// are skipped, since terminals theme them freely.
+func ansi256(r, g, b uint8) int {
+	nearestLevel := func(v uint8) int {
+		best := 0
+		for i, level := range cubeLevels {
+			if abs(int(v)-level) < abs(int(v)-cubeLevels[best]) {
+				best = i
+			}
+		}
+		return best
+	}
+
+	ri, gi, bi := nearestLevel(r), nearestLevel(g), nearestLevel(b)
+	cube := 16 + 36*ri + 6*gi + bi
+	cubeDist := distance(r, g, b, cubeLevels[ri], cubeLevels[gi], cubeLevels[bi])
+
+	// The gray ramp runs from 8 to 238 in steps of 10.
+	average := (int(r) + int(g) + int(b)) / 3
+	step := min(max((average-8+5)/10, 0), 23)
+	grayLevel := 8 + 10*step
+	grayDist := distance(r, g, b, grayLevel, grayLevel, grayLevel)
+
+	if grayDist < cubeDist {
+		return 232 + step
+	}
+	return cube
+}
+
+// ansi16 returns the index of the basic ANSI color matching r, g, b: black,
+// gray, or white for muted colors, and otherwise the red, yellow, green, cyan,
+// blue, or magenta of its hue, bright unless the color is dark.
+func ansi16(r, g, b uint8) int {
+	highest := max(r, g, b)
+	lowest := min(r, g, b)
+	chroma := int(highest) - int(lowest)
+
+	if chroma < 0x30 {
+		switch lightness := (int(highest) + int(lowest)) / 2; {
+		case lightness < 0x40:
+			return 0
+		case lightness < 0x99:
+			return 8
+		case lightness < 0xd9:
+			return 7
+		default:
+			return 15
+		}
+	}
+
+	var base int
+	switch hue := hueDegrees(r, g, b); {
+	case hue < 20 || hue >= 330:
+		base = 1 // red
+	case hue < 75:
+		base = 3 // yellow
+	case hue < 160:
+		base = 2 // green
+	case hue < 200:
+		base = 6 // cyan
+	case hue < 270:
+		base = 4 // blue
+	default:
+		base = 5 // magenta
+	}
+
+	if highest >= 0x99 {
+		return base + 8
+	}
+	return base
+}
+
+// hueDegrees returns the hue of r, g, b in degrees, from 0 up to 360.
+func hueDegrees(r, g, b uint8) float64 {
+	rf, gf, bf := float64(r), float64(g), float64(b)
+	highest := max(rf, gf, bf)
+	chroma := highest - min(rf, gf, bf)
+	if chroma == 0 {
+		return 0
+	}
+
+	var hue float64
+	switch highest {
+	case rf:
+		hue = math.Mod((gf-bf)/chroma, 6)
+	case gf:
+		hue = (bf-rf)/chroma + 2
+	default:
+		hue = (rf-gf)/chroma + 4
+	}
+
+	hue *= 60
+	if hue < 0 {
+		hue += 360
+	}
+	return hue
+}
+
+// distance returns the squared distance between two RGB colors.
+func distance(r, g, b uint8, r2, g2, b2 int) int {
+	dr, dg, db := int(r)-r2, int(g)-g2, int(b)-b2
+	return dr*dr + dg*dg + db*db
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
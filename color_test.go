@@ -0,0 +1,48 @@
+package bark
+
+import (
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func TestDefaultColorANSIIndexes(t *testing.T) {
+	tests := []struct {
+		name    string
+		hex     string
+		ansi256 string
+		ansi16  string
+	}{
+		{"info", builtinOptions.InfoHex, "32", "12"},
+		{"warn", builtinOptions.WarnHex, "221", "11"},
+		{"error", builtinOptions.ErrorHex, "203", "9"},
+		{"debug", builtinOptions.DebugHex, "177", "13"},
+		{"trace", builtinOptions.TraceHex, "103", "7"},
+		{"success", builtinOptions.SuccessHex, "112", "10"},
+		{"notice", builtinOptions.NoticeHex, "81", "14"},
+		{"panic", builtinOptions.PanicHex, "203", "9"},
+
+		{"info light", builtinOptions.InfoHexLight, "24", "4"},
+		{"warn light", builtinOptions.WarnHexLight, "136", "11"},
+		{"error light", builtinOptions.ErrorHexLight, "160", "9"},
+		{"debug light", builtinOptions.DebugHexLight, "91", "13"},
+		{"trace light", builtinOptions.TraceHexLight, "60", "8"},
+		{"success light", builtinOptions.SuccessHexLight, "64", "2"},
+		{"notice light", builtinOptions.NoticeHexLight, "31", "12"},
+		{"panic light", builtinOptions.PanicHexLight, "160", "9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := profileColor(tt.hex, termenv.ANSI256); got != tt.ansi256 {
+				t.Errorf("256-color index of %s = %s, want %s", tt.hex, got, tt.ansi256)
+			}
+			if got := profileColor(tt.hex, termenv.ANSI); got != tt.ansi16 {
+				t.Errorf("16-color index of %s = %s, want %s", tt.hex, got, tt.ansi16)
+			}
+			if got := profileColor(tt.hex, termenv.TrueColor); got != tt.hex {
+				t.Errorf("truecolor value of %s = %s, want it unchanged", tt.hex, got)
+			}
+		})
+	}
+}
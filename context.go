@@ -0,0 +1,67 @@
+package bark
+
+import "context"
+
+type contextKey struct{}
+
+// WithContext returns a copy of ctx that carries logger.
+// Pair it with With to stash a request-scoped child Logger once,
+// e.g. in middleware, and retrieve it further down with FromContext.
+func WithContext(ctx context.Context, logger *Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the Logger stored in ctx by WithContext.
+// If ctx carries no Logger, the package default is returned.
+func FromContext(ctx context.Context) *Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(contextKey{}).(*Logger); ok && logger != nil {
+			return logger
+		}
+	}
+
+	return std
+}
+
+// InfoCtx logs a message at Info level using the Logger stored in ctx.
+func InfoCtx(ctx context.Context, msg string, keyvals ...any) {
+	FromContext(ctx).log(InfoLevel, msg, keyvals...)
+}
+
+// SuccessCtx logs a message at Success level using the Logger stored in ctx.
+func SuccessCtx(ctx context.Context, msg string, keyvals ...any) {
+	FromContext(ctx).log(SuccessLevel, msg, keyvals...)
+}
+
+// NoticeCtx logs a message at Notice level using the Logger stored in ctx.
+func NoticeCtx(ctx context.Context, msg string, keyvals ...any) {
+	FromContext(ctx).log(NoticeLevel, msg, keyvals...)
+}
+
+// WarnCtx logs a message at Warn level using the Logger stored in ctx.
+func WarnCtx(ctx context.Context, msg string, keyvals ...any) {
+	FromContext(ctx).log(WarnLevel, msg, keyvals...)
+}
+
+// ErrorCtx logs a message at Error level using the Logger stored in ctx.
+func ErrorCtx(ctx context.Context, msg string, keyvals ...any) {
+	FromContext(ctx).log(ErrorLevel, msg, keyvals...)
+}
+
+// FatalCtx logs a message at Fatal level using the Logger stored in ctx
+// and terminates the program.
+func FatalCtx(ctx context.Context, msg string, keyvals ...any) {
+	logger := FromContext(ctx)
+	logger.log(FatalLevel, msg, keyvals...)
+	logger.exit(msg)
+}
+
+// DebugCtx logs a message at Debug level using the Logger stored in ctx.
+func DebugCtx(ctx context.Context, msg string, keyvals ...any) {
+	FromContext(ctx).log(DebugLevel, msg, keyvals...)
+}
+
+// TraceCtx logs a message at Trace level using the Logger stored in ctx.
+func TraceCtx(ctx context.Context, msg string, keyvals ...any) {
+	FromContext(ctx).log(TraceLevel, msg, keyvals...)
+}
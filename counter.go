@@ -0,0 +1,84 @@
+package bark
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// levelCounts and byteCounts hold an *atomic.Uint64 per level that has been
+// logged at, counting entries and bytes written. Counters are created once and
+// never removed, so counting is lock-free.
+var levelCounts, byteCounts sync.Map
+
+// addCount adds n to the counter of level in counts.
+func addCount(counts *sync.Map, level Level, n uint64) {
+	counter, ok := counts.Load(level)
+	if !ok {
+		counter, _ = counts.LoadOrStore(level, new(atomic.Uint64))
+	}
+	counter.(*atomic.Uint64).Add(n)
+}
+
+// loadCounts returns the value of every counter in counts.
+func loadCounts(counts *sync.Map) map[Level]uint64 {
+	values := map[Level]uint64{}
+	counts.Range(func(level, counter any) bool {
+		values[level.(Level)] = counter.(*atomic.Uint64).Load()
+		return true
+	})
+	return values
+}
+
+// resetCounts sets every counter in counts to zero.
+func resetCounts(counts *sync.Map) {
+	counts.Range(func(_, counter any) bool {
+		counter.(*atomic.Uint64).Store(0)
+		return true
+	})
+}
+
+// countEntry adds one to the count of entries logged at level.
+func countEntry(level Level) {
+	addCount(&levelCounts, level, 1)
+}
+
+// LogCounter returns how many entries have been logged at each level since Init
+// or ResetCounters was last called, e.g. to alert when errors spike. Entries
+// hidden by the level or a filter aren't counted, and neither is Print output.
+// Levels nothing was logged at may be missing or zero.
+func LogCounter() map[Level]uint64 {
+	return loadCounts(&levelCounts)
+}
+
+// ResetCounters sets the count of every level reported by LogCounter to zero.
+func ResetCounters() {
+	resetCounts(&levelCounts)
+}
+
+// BytesWritten returns how many bytes have been written to sinks at each level
+// since Init or ResetByteCounters was last called, summed over every sink, e.g. to
+// size buffers or spot a flood of logs. Every byte of an entry counts, styling
+// included. Print output counts under PrintLevel. Levels nothing was written at
+// may be missing or zero.
+func BytesWritten() map[Level]uint64 {
+	return loadCounts(&byteCounts)
+}
+
+// ResetByteCounters sets the count of every level reported by BytesWritten to zero.
+func ResetByteCounters() {
+	resetCounts(&byteCounts)
+}
+
+// countingWriter counts the bytes written through it to a sink's writer under
+// level, which the sink sets before each entry while holding its lock.
+type countingWriter struct {
+	w     io.Writer
+	level Level
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	addCount(&byteCounts, c.level, uint64(n))
+	return n, err
+}
@@ -0,0 +1,173 @@
+package bark
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+)
+
+var (
+	registeredMu sync.RWMutex
+	// registeredLevels are the levels added with RegisterLevel.
+	registeredLevels = map[Level]registeredLevel{}
+)
+
+// registeredLevel is a user-defined level's name and badge style.
+type registeredLevel struct {
+	name  string
+	style lipgloss.Style
+}
+
+// builtinLevels are the levels bark defines, by name. RegisterLevel refuses
+// to reuse their names or weights.
+var builtinLevels = map[string]Level{
+	"trace":   TraceLevel,
+	"debug":   DebugLevel,
+	"info":    InfoLevel,
+	"success": SuccessLevel,
+	"notice":  NoticeLevel,
+	"warn":    WarnLevel,
+	"error":   ErrorLevel,
+	"panic":   PanicLevel,
+	"fatal":   FatalLevel,
+	"print":   PrintLevel,
+}
+
+// RegisterLevel adds a level of its own to bark and returns it, for use with Log
+// and Logf. weight orders it among the other levels, which are spaced out to leave
+// room: Trace is -8, Debug -4, Info 0, Success 1, Notice 2, Warn 4, Error 8, Panic 10,
+// and Fatal 12.
+// Entries at the new level are filtered by weight like any other:
+//
+//	audit, err := bark.RegisterLevel("audit", 6, lipgloss.NewStyle().Foreground(lipgloss.Color("#ff924c")).Bold(true))
+//	...
+//	bark.Log(audit, "user deleted", "id", id)
+//
+// Pretty sinks render the level with style, showing the name in upper case unless
+// style already has a string set with SetString. Structured formats report it
+// by name in a "level" field. The global sinks, including ones configured by an
+// earlier Init, are restyled right away; sinks of Clones and test Loggers are not.
+//
+// RegisterLevel returns an error if name is empty or already taken,
+// whether by a built-in level or a registered one, or if weight is.
+func RegisterLevel(name string, weight int, style lipgloss.Style) (Level, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	level := Level(weight)
+
+	if name == "" {
+		return 0, fmt.Errorf("RegisterLevel: name is empty")
+	}
+	if _, ok := builtinLevels[name]; ok {
+		return 0, fmt.Errorf("RegisterLevel: %q is a built-in level", name)
+	}
+	for _, builtin := range builtinLevels {
+		if level == builtin {
+			return 0, fmt.Errorf("RegisterLevel: weight %d is used by the built-in %s level", weight, levelName(level))
+		}
+	}
+
+	if style.Value() == "" {
+		style = style.SetString(strings.ToUpper(name))
+	}
+
+	registeredMu.Lock()
+	defer registeredMu.Unlock()
+
+	for existing, registered := range registeredLevels {
+		if registered.name == name {
+			return 0, fmt.Errorf("RegisterLevel: %q is already registered", name)
+		}
+		if existing == level {
+			return 0, fmt.Errorf("RegisterLevel: weight %d is already used by %q", weight, registered.name)
+		}
+	}
+	registeredLevels[level] = registeredLevel{name: name, style: style}
+
+	sinksMu.RLock()
+	active := sinks
+	sinksMu.RUnlock()
+
+	for _, s := range active {
+		s.setLevelStyle(level, style)
+	}
+
+	return level, nil
+}
+
+// registeredLevelName returns the name level was registered with, if any.
+func registeredLevelName(level Level) (string, bool) {
+	registeredMu.RLock()
+	defer registeredMu.RUnlock()
+
+	registered, ok := registeredLevels[level]
+	return registered.name, ok
+}
+
+// registeredLevelByName returns the registered level called name, compared case-insensitively.
+func registeredLevelByName(name string) (Level, bool) {
+	registeredMu.RLock()
+	defer registeredMu.RUnlock()
+
+	for level, registered := range registeredLevels {
+		if strings.EqualFold(registered.name, name) {
+			return level, true
+		}
+	}
+	return 0, false
+}
+
+// addRegisteredStyles adds the badge style of every registered level to styles.
+func addRegisteredStyles(styles *log.Styles) {
+	registeredMu.RLock()
+	defer registeredMu.RUnlock()
+
+	for level, registered := range registeredLevels {
+		styles.Levels[level] = registered.style
+	}
+}
+
+// setLevelStyle makes s render level with style. Structured sinks are left alone,
+// since their formatters name the level through a field instead.
+func (s *sink) setLevelStyle(level Level, style lipgloss.Style) {
+	if s.format.structured() {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	styles := *s.styles
+	styles.Levels = maps.Clone(s.styles.Levels)
+	styles.Levels[level] = style
+
+	s.styles = &styles
+	s.logger.SetStyles(s.styles)
+}
+
+// Log logs a message at the given level, which may be one added with RegisterLevel.
+// Unlike Fatal, it does not exit, even at FatalLevel.
+func Log(level Level, msg string, keyvals ...any) {
+	std.log(level, msg, keyvals...)
+}
+
+// Logf logs a formatted message at the given level, which may be one added with RegisterLevel.
+// Unlike Fatalf, it does not exit, even at FatalLevel.
+func Logf(level Level, formatMsg string, vals ...any) {
+	std.logf(level, formatMsg, vals...)
+}
+
+// Log logs a message at the given level, which may be one added with RegisterLevel.
+// Unlike Fatal, it does not exit, even at FatalLevel.
+func (l *Logger) Log(level Level, msg string, keyvals ...any) {
+	l.log(level, msg, keyvals...)
+}
+
+// Logf logs a formatted message at the given level, which may be one added with RegisterLevel.
+// Unlike Fatalf, it does not exit, even at FatalLevel.
+func (l *Logger) Logf(level Level, formatMsg string, vals ...any) {
+	l.logf(level, formatMsg, vals...)
+}
@@ -0,0 +1,85 @@
+package bark
+
+import (
+	"sync"
+	"time"
+)
+
+var (
+	datadogMu      sync.RWMutex
+	datadogService string
+	datadogVersion string
+)
+
+// SetDatadogService attaches a "service" field with the given name to every
+// FormatDatadog entry, replacing any "service" field of the entry itself.
+// Passing an empty string removes the field.
+func SetDatadogService(name string) {
+	datadogMu.Lock()
+	defer datadogMu.Unlock()
+	datadogService = name
+}
+
+// SetDatadogVersion attaches a "version" field with the given version to every
+// FormatDatadog entry, replacing any "version" field of the entry itself,
+// such as one from SetVersion. Passing an empty string removes the field.
+func SetDatadogVersion(version string) {
+	datadogMu.Lock()
+	defer datadogMu.Unlock()
+	datadogVersion = version
+}
+
+// datadogEnvelope returns fields preceded by the timestamp, status, message,
+// service, and version of an entry, with any trace_id and span_id fields
+// moved to the keys Datadog reads.
+func datadogEnvelope(ts time.Time, level Level, msg string, fields []any) []any {
+	datadogMu.RLock()
+	service, version := datadogService, datadogVersion
+	datadogMu.RUnlock()
+
+	envelope := []any{
+		rawKey("timestamp"), ts.UTC().Format(time.RFC3339Nano),
+		rawKey("status"), datadogStatus(level),
+		rawKey("message"), msg,
+	}
+	if service != "" {
+		envelope = append(envelope, rawKey("service"), service)
+	}
+	if version != "" {
+		envelope = append(envelope, rawKey("version"), version)
+	}
+
+	return liftFields(envelope, fields, func(key string, value any) []any {
+		switch {
+		case key == "trace_id":
+			return []any{rawKey("dd.trace_id"), value}
+		case key == "span_id":
+			return []any{rawKey("dd.span_id"), value}
+		case key == "service" && service != "", key == "version" && version != "":
+			// Dropped in favor of the one in the envelope.
+			return []any{}
+		}
+		return nil
+	})
+}
+
+// datadogStatus returns the Datadog status closest to level. Levels added
+// with RegisterLevel take the status of the nearest built-in level below them.
+func datadogStatus(level Level) string {
+	switch {
+	case level == PrintLevel:
+		return "info"
+	case level >= PanicLevel:
+		return "critical"
+	case level >= ErrorLevel:
+		return "error"
+	case level >= WarnLevel:
+		return "warn"
+	case level >= NoticeLevel:
+		return "notice"
+	case level >= InfoLevel:
+		return "info"
+	default:
+		return "debug"
+	}
+}
@@ -0,0 +1,92 @@
+package bark
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	debugScopesMu sync.Mutex
+	// debugScopes counts the active debug scopes of each goroutine, by goroutine ID.
+	debugScopes = map[uint64]int{}
+	// activeDebugScopes is the number of goroutines in a debug scope,
+	// so log calls can skip looking up their goroutine when there are none.
+	activeDebugScopes atomic.Int32
+)
+
+// WithDebugScope runs fn with Debug entries enabled for log calls made on the
+// current goroutine, whatever the levels that would otherwise apply, e.g. to look
+// closely at one suspicious operation:
+//
+//	bark.WithDebugScope(func() {
+//		reconcile(state)
+//	})
+//
+// Log calls from other goroutines, including ones fn starts, are unaffected.
+// The scope ends when fn returns, even if it panics. Scopes may nest.
+//
+// Identifying the goroutine costs a few microseconds, paid only by entries
+// below the level while a debug scope is active somewhere in the program.
+func WithDebugScope(fn func()) {
+	restore := EnterDebugScope()
+	defer restore()
+	fn()
+}
+
+// EnterDebugScope enables Debug entries for log calls made on the current
+// goroutine until restore is called, which must happen on the same goroutine.
+// See WithDebugScope.
+func EnterDebugScope() (restore func()) {
+	id := goroutineID()
+
+	debugScopesMu.Lock()
+	if debugScopes[id] == 0 {
+		activeDebugScopes.Add(1)
+	}
+	debugScopes[id]++
+	debugScopesMu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			debugScopesMu.Lock()
+			defer debugScopesMu.Unlock()
+
+			debugScopes[id]--
+			if debugScopes[id] == 0 {
+				delete(debugScopes, id)
+				activeDebugScopes.Add(-1)
+			}
+		})
+	}
+}
+
+// inDebugScope reports whether level is enabled by a debug scope on the current goroutine.
+func inDebugScope(level Level) bool {
+	if level < DebugLevel || activeDebugScopes.Load() == 0 {
+		return false
+	}
+
+	id := goroutineID()
+
+	debugScopesMu.Lock()
+	defer debugScopesMu.Unlock()
+	return debugScopes[id] > 0
+}
+
+// goroutineID returns the ID of the current goroutine, parsed from the
+// "goroutine 123 [running]:" header of its stack trace.
+func goroutineID() uint64 {
+	var buf [64]byte
+	header := buf[:runtime.Stack(buf[:], false)]
+	header = bytes.TrimPrefix(header, []byte("goroutine "))
+	if i := bytes.IndexByte(header, ' '); i >= 0 {
+		header = header[:i]
+	}
+
+	id, _ := strconv.ParseUint(string(header), 10, 64)
+	return id
+}
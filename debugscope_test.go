@@ -0,0 +1,68 @@
+package bark_test
+
+import (
+	"sync"
+	"testing"
+
+	"go.dalton.dog/bark"
+)
+
+func TestDebugScopeEnablesDebugOnItsGoroutine(t *testing.T) {
+	restore, capture := bark.CaptureGlobal()
+	defer restore()
+
+	if bark.DebugEnabled() {
+		t.Fatal("Debug is enabled outside any debug scope")
+	}
+
+	bark.WithDebugScope(func() {
+		if !bark.DebugEnabled() {
+			t.Error("Debug isn't enabled inside a debug scope")
+		}
+		if bark.Enabled(bark.TraceLevel) {
+			t.Error("Trace is enabled inside a debug scope")
+		}
+		bark.Debug("inside the scope")
+
+		// Other goroutines are unaffected.
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if bark.DebugEnabled() {
+				t.Error("Debug is enabled on a goroutine started in a debug scope")
+			}
+			bark.Debug("from another goroutine")
+		}()
+		wg.Wait()
+	})
+
+	if bark.DebugEnabled() {
+		t.Error("Debug is still enabled after the debug scope ended")
+	}
+	bark.Debug("after the scope")
+
+	bark.AssertLogged(t, capture, bark.DebugLevel, "inside the scope")
+	bark.AssertNotLogged(t, capture, bark.DebugLevel, "from another goroutine")
+	bark.AssertNotLogged(t, capture, bark.DebugLevel, "after the scope")
+}
+
+func TestDebugScopeRespectsMaxLevel(t *testing.T) {
+	restore, _ := bark.CaptureGlobal()
+	defer restore()
+	defer bark.Reset()
+
+	bark.SetLevel(bark.WarnLevel)
+	if err := bark.SetMaxLevel(bark.WarnLevel); err != nil {
+		t.Fatal(err)
+	}
+
+	bark.WithDebugScope(func() {
+		if !bark.DebugEnabled() {
+			t.Error("Debug isn't enabled inside a debug scope below the level")
+		}
+		if bark.Enabled(bark.ErrorLevel) {
+			t.Error("Error is enabled above the maximum level inside a debug scope")
+		}
+	})
+}
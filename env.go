@@ -0,0 +1,34 @@
+package bark
+
+import (
+	"fmt"
+	"os"
+)
+
+// applyLevelEnv sets the global level from the environment variable called name,
+// if it is set. An invalid value is reported to targets with a single Warn entry,
+// and the level falls back to Info.
+func applyLevelEnv(name string, targets []*sink) {
+	if name == "" {
+		return
+	}
+
+	value := os.Getenv(name)
+	if value == "" {
+		return
+	}
+
+	level, err := ParseLevel(value)
+	if err != nil {
+		globalLevel.Store(int64(InfoLevel))
+		updateMinLevel()
+
+		// Logging through a Logger would wait on the auto-initialization
+		// that may be running this.
+		writeAll(targets, WarnLevel, fmt.Sprintf("ignoring %s, using info level", name), "error", err)
+		return
+	}
+
+	globalLevel.Store(int64(level))
+	updateMinLevel()
+}
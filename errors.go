@@ -0,0 +1,259 @@
+package bark
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/log"
+)
+
+// NewError creates a new error with the provided message.
+func NewError(msg string) error {
+	return errors.New(msg)
+}
+
+// NewErrorf creates a new formatted error using the format string and arguments.
+func NewErrorf(formatMsg string, vals ...any) error {
+	return fmt.Errorf(formatMsg, vals...)
+}
+
+// BarkError is an error carrying a numeric code for machine-readable categorization.
+type BarkError struct {
+	code int
+	err  error
+}
+
+// NewErrorWithCode creates a new error with the provided code and message.
+func NewErrorWithCode(code int, msg string) *BarkError {
+	return &BarkError{code: code, err: errors.New(msg)}
+}
+
+// NewErrorWithCodef creates a new error with the provided code and a message
+// formatted from the format string and arguments. Errors wrapped with %w
+// remain reachable through errors.Is and errors.As.
+func NewErrorWithCodef(code int, formatMsg string, vals ...any) *BarkError {
+	return &BarkError{code: code, err: fmt.Errorf(formatMsg, vals...)}
+}
+
+// Error returns the error message.
+func (e *BarkError) Error() string {
+	return e.err.Error()
+}
+
+// Code returns the error's numeric code.
+func (e *BarkError) Code() int {
+	return e.code
+}
+
+// Unwrap returns any error wrapped by the format string passed to NewErrorWithCodef.
+func (e *BarkError) Unwrap() error {
+	return errors.Unwrap(e.err)
+}
+
+// ErrorCode returns the code of the first BarkError in err's chain,
+// and false if there is none.
+func ErrorCode(err error) (int, bool) {
+	var barkErr *BarkError
+	if errors.As(err, &barkErr) {
+		return barkErr.Code(), true
+	}
+	return 0, false
+}
+
+// annotatedError wraps an error with structured key/value pairs.
+type annotatedError struct {
+	err     error
+	keyvals []any
+}
+
+// Annotate wraps err with keyvals, adding structured context without losing
+// the original error. The result renders as "msg; key=val key=val" and the
+// pairs can be recovered with AnnotationFields. Annotate returns nil if err is nil.
+func Annotate(err error, keyvals ...any) error {
+	if err == nil {
+		return nil
+	}
+
+	if len(keyvals)%2 != 0 {
+		keyvals = append(keyvals, log.ErrMissingValue)
+	}
+
+	return &annotatedError{err: err, keyvals: keyvals}
+}
+
+func (e *annotatedError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.err.Error())
+	b.WriteString(";")
+
+	for i := 0; i < len(e.keyvals); i += 2 {
+		fmt.Fprintf(&b, " %v=%v", e.keyvals[i], e.keyvals[i+1])
+	}
+
+	return b.String()
+}
+
+func (e *annotatedError) Unwrap() error {
+	return e.err
+}
+
+// AnnotationFields returns the key/value pairs attached by Annotate anywhere in
+// err's chain. Pairs from inner errors come first, so when the result is logged,
+// an outer annotation of the same key takes precedence.
+func AnnotationFields(err error) []any {
+	var fields []any
+	for err != nil {
+		if annotated, ok := err.(*annotatedError); ok {
+			fields = append(append([]any(nil), annotated.keyvals...), fields...)
+		}
+		err = errors.Unwrap(err)
+	}
+	return fields
+}
+
+// errorMessage returns err's message without the rendered annotations
+// of any annotatedError wrapping it directly.
+func errorMessage(err error) string {
+	for {
+		annotated, ok := err.(*annotatedError)
+		if !ok {
+			return err.Error()
+		}
+		err = annotated.err
+	}
+}
+
+// ErrorGroup collects multiple errors, such as the results of validating many fields,
+// so they can be returned together. It is safe for concurrent use.
+type ErrorGroup struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+// NewErrorGroup creates an empty ErrorGroup.
+func NewErrorGroup() *ErrorGroup {
+	return &ErrorGroup{}
+}
+
+// Add appends err to the group. Nil errors are ignored.
+func (eg *ErrorGroup) Add(err error) {
+	if err == nil {
+		return
+	}
+
+	eg.mu.Lock()
+	defer eg.mu.Unlock()
+	eg.errs = append(eg.errs, err)
+}
+
+// Err returns the group as an error, or nil if no errors were added.
+func (eg *ErrorGroup) Err() error {
+	if len(eg.Errors()) == 0 {
+		return nil
+	}
+	return eg
+}
+
+// Errors returns a copy of the errors added so far.
+func (eg *ErrorGroup) Errors() []error {
+	eg.mu.Lock()
+	defer eg.mu.Unlock()
+	return append([]error(nil), eg.errs...)
+}
+
+// Error renders the errors as a numbered list, one per line.
+func (eg *ErrorGroup) Error() string {
+	errs := eg.Errors()
+
+	lines := make([]string, len(errs))
+	for i, err := range errs {
+		lines[i] = fmt.Sprintf("%d. %v", i+1, err)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Unwrap returns the collected errors so errors.Is and errors.As can inspect them.
+func (eg *ErrorGroup) Unwrap() []error {
+	return eg.Errors()
+}
+
+// LogErrorGroup logs each error in eg as a separate entry at the given level.
+func LogErrorGroup(level Level, eg *ErrorGroup) {
+	if eg == nil {
+		return
+	}
+
+	for _, err := range eg.Errors() {
+		fields := AnnotationFields(err)
+		if level >= ErrorLevel {
+			fields = append(fields, stackField(err)...)
+		}
+		std.log(level, errorMessage(err), fields...)
+	}
+}
+
+// StackError is an error that records the call stack at the point it was created.
+type StackError struct {
+	error
+	frames []uintptr
+}
+
+// Frame is a single entry in a stack trace.
+type Frame struct {
+	File     string
+	Line     int
+	Function string
+}
+
+// NewErrorWithStack creates a new error with the provided message,
+// capturing the stack of the caller. When such an error is logged at Error
+// level or above, the stack is printed along with it.
+func NewErrorWithStack(msg string) *StackError {
+	const maxDepth = 64
+	pcs := make([]uintptr, maxDepth)
+	// Skip runtime.Callers and NewErrorWithStack itself.
+	n := runtime.Callers(2, pcs)
+
+	return &StackError{error: errors.New(msg), frames: pcs[:n]}
+}
+
+// StackTrace returns the frames recorded by the first StackError in err's chain,
+// innermost call first, or nil if there is none.
+func StackTrace(err error) []Frame {
+	var stackErr *StackError
+	if !errors.As(err, &stackErr) {
+		return nil
+	}
+
+	var trace []Frame
+	frames := runtime.CallersFrames(stackErr.frames)
+	for {
+		frame, more := frames.Next()
+		trace = append(trace, Frame{File: frame.File, Line: frame.Line, Function: frame.Function})
+		if !more {
+			break
+		}
+	}
+
+	return trace
+}
+
+// stackField returns a "stack" key/value pair rendering err's stack trace,
+// or nil if err carries none.
+func stackField(err error) []any {
+	trace := StackTrace(err)
+	if trace == nil {
+		return nil
+	}
+
+	lines := make([]string, len(trace))
+	for i, frame := range trace {
+		lines[i] = fmt.Sprintf("%s\n    %s:%d", frame.Function, frame.File, frame.Line)
+	}
+
+	return []any{"stack", strings.Join(lines, "\n")}
+}
@@ -0,0 +1,29 @@
+package bark_test
+
+import (
+	"fmt"
+
+	"go.dalton.dog/bark"
+)
+
+// loadConfig stands in for code under test that gives up with bark.Fatal.
+func loadConfig(path string) {
+	bark.Fatal("config file not found", "path", path)
+}
+
+func ExampleMockFatal() {
+	restore := bark.MockFatal()
+	defer restore()
+
+	defer func() {
+		if fatal, ok := recover().(bark.FatalExit); ok {
+			fmt.Println(fatal.Message)
+			fmt.Println("exit code:", fatal.Code)
+		}
+	}()
+
+	loadConfig("missing.toml")
+	// Output:
+	// config file not found
+	// exit code: 1
+}
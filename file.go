@@ -0,0 +1,118 @@
+package bark
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// rotatedTimeFormat is the timestamp appended to the names of rotated log files.
+const rotatedTimeFormat = "20060102T150405"
+
+// AddFileLogger adds a sink appending entries to the file at path, creating it
+// if needed, alongside those configured by Init. The file is rotated by RotateFiles,
+// and by SIGUSR2 once RegisterRotationSignal is called: it is renamed to path with
+// a timestamp appended, e.g. app.log.20240102T150405, and a fresh file is opened at path.
+//
+// Entries are written uncolored unless opts say otherwise. Like AddWriterLogger,
+// the sink is replaced by the next Init, and closed by Reset and Shutdown.
+func AddFileLogger(path string, opts ...Option) error {
+	f, err := OpenRotatingFile(path, true)
+	if err != nil {
+		return fmt.Errorf("AddFileLogger: %v", err)
+	}
+
+	err = AddWriterLogger(f, append([]Option{WithColor(ColorNever)}, opts...)...)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	return nil
+}
+
+// RotatingFile is a log file that implements FileRotator, for use with AddWriterLogger.
+// It is safe for concurrent use.
+type RotatingFile struct {
+	path   string
+	rename bool
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+// OpenRotatingFile opens the file at path for appending, creating it if needed.
+// When rotated, it renames the file to path with a timestamp appended if rename
+// is true, then opens path afresh. With rename false it only reopens path, for
+// files that a tool such as logrotate has already moved aside.
+func OpenRotatingFile(path string, rename bool) (*RotatingFile, error) {
+	f, err := openLogFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return &RotatingFile{path: path, rename: rename, file: f}, nil
+}
+
+// Write appends p to the current file.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.file.Write(p)
+}
+
+// Close closes the current file.
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.file.Close()
+}
+
+// Rotate closes the current file and opens a new one at the same path, first
+// renaming the current file if f renames on rotation. It returns the name the
+// closed file ends up with and the name of the new one. If the new file can't
+// be opened, f keeps writing to the old one.
+func (f *RotatingFile) Rotate() (oldName, newName string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	oldName = f.path
+	if f.rename {
+		oldName = rotatedName(f.path)
+		if err := os.Rename(f.path, oldName); err != nil {
+			return "", "", err
+		}
+	}
+
+	next, err := openLogFile(f.path)
+	if err != nil {
+		return "", "", err
+	}
+
+	f.file.Close()
+	f.file = next
+	return oldName, f.path, nil
+}
+
+func (f *RotatingFile) sinkKind() string {
+	return "file"
+}
+
+// openLogFile opens path for appending, creating it if needed.
+func openLogFile(path string) (*os.File, error) {
+	return os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+}
+
+// rotatedName returns the name to rename path to when rotating it: path with
+// the current time appended, and a counter too if that name is taken.
+func rotatedName(path string) string {
+	name := path + "." + now().Format(rotatedTimeFormat)
+	candidate := name
+	for i := 1; ; i++ {
+		if _, err := os.Lstat(candidate); os.IsNotExist(err) {
+			return candidate
+		}
+		candidate = name + "." + strconv.Itoa(i)
+	}
+}
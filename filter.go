@@ -0,0 +1,200 @@
+package bark
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var (
+	filterMu sync.RWMutex
+	filter   func(level Level, msg string, keyvals []any) bool
+
+	// suppressPrefixes and allowPrefixes are set with SuppressPrefix and AllowOnlyPrefix.
+	suppressPrefixes []string
+	allowPrefixes    []string
+
+	// suppressPatterns and allowPatterns are set with SuppressMatching and AllowOnlyMatching.
+	suppressPatterns []*regexp.Regexp
+	allowPatterns    []*regexp.Regexp
+
+	// suppressFields are set with SuppressWhenFieldEquals.
+	suppressFields []fieldMatch
+)
+
+// fieldMatch is a key and the value that suppresses entries carrying it.
+type fieldMatch struct {
+	key   string
+	value any
+}
+
+// SetFilter makes every entry that passes level filtering go through fn first:
+// when fn returns false, the entry is silently dropped before reaching any sink
+// or hook. keyvals holds all of the entry's fields, including those attached with
+// With and the application metadata, in the order they would be written.
+// Setting a filter replaces the previous one.
+//
+// The filter rules added with SuppressPrefix, SuppressMatching, SuppressWhenFieldEquals,
+// AllowOnlyPrefix, and AllowOnlyMatching accumulate, and combine with each other and
+// with fn as follows: suppress rules combine with OR, so an entry matching any of
+// them is dropped. An entry is kept by the allow rules only if it starts with one
+// of the allowed prefixes, if any, and matches every allowed pattern. An entry must
+// pass both, and then fn, to be logged.
+func SetFilter(fn func(level Level, msg string, keyvals []any) bool) {
+	filterMu.Lock()
+	defer filterMu.Unlock()
+	filter = fn
+}
+
+// ClearFilter removes the filter set with SetFilter, so every entry passes again.
+func ClearFilter() {
+	SetFilter(nil)
+}
+
+// SuppressPrefix drops every entry whose message starts with prefix.
+// See SetFilter for how it combines with other filters.
+func SuppressPrefix(prefix string) {
+	filterMu.Lock()
+	defer filterMu.Unlock()
+	suppressPrefixes = append(suppressPrefixes, prefix)
+}
+
+// AllowOnlyPrefix drops every entry whose message does not start with prefix.
+// When called more than once, an entry is kept if it starts with any of the
+// allowed prefixes. Suppressed prefixes apply on top.
+func AllowOnlyPrefix(prefix string) {
+	filterMu.Lock()
+	defer filterMu.Unlock()
+	allowPrefixes = append(allowPrefixes, prefix)
+}
+
+// ClearPrefixFilters removes every filter added with SuppressPrefix and AllowOnlyPrefix.
+func ClearPrefixFilters() {
+	filterMu.Lock()
+	defer filterMu.Unlock()
+	suppressPrefixes = nil
+	allowPrefixes = nil
+}
+
+// SuppressMatching drops every entry whose message matches the regular expression
+// pattern, returning an error if it doesn't compile. See SetFilter for how it
+// combines with other filters.
+func SuppressMatching(pattern string) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("SuppressMatching: %v", err)
+	}
+
+	filterMu.Lock()
+	defer filterMu.Unlock()
+	suppressPatterns = append(suppressPatterns, re)
+
+	return nil
+}
+
+// AllowOnlyMatching drops every entry whose message doesn't match the regular
+// expression pattern, returning an error if it doesn't compile. See SetFilter for
+// how it combines with other filters.
+func AllowOnlyMatching(pattern string) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("AllowOnlyMatching: %v", err)
+	}
+
+	filterMu.Lock()
+	defer filterMu.Unlock()
+	allowPatterns = append(allowPatterns, re)
+
+	return nil
+}
+
+// ClearMatchingFilters removes every filter added with SuppressMatching and AllowOnlyMatching.
+func ClearMatchingFilters() {
+	filterMu.Lock()
+	defer filterMu.Unlock()
+	suppressPatterns = nil
+	allowPatterns = nil
+}
+
+// SuppressWhenFieldEquals drops every entry with a field named key whose value
+// equals value, as compared by reflect.DeepEqual, e.g. to silence a noisy tenant:
+//
+//	bark.SuppressWhenFieldEquals("tenant", "load-test")
+//
+// Fields attached with With or pushed scopes count as well as those passed to
+// the log call. See SetFilter for how it combines with other filters.
+func SuppressWhenFieldEquals(key string, value any) {
+	filterMu.Lock()
+	defer filterMu.Unlock()
+	suppressFields = append(suppressFields, fieldMatch{key: key, value: value})
+}
+
+// ClearFieldFilters removes every filter added with SuppressWhenFieldEquals.
+func ClearFieldFilters() {
+	filterMu.Lock()
+	defer filterMu.Unlock()
+	suppressFields = nil
+}
+
+// filtered reports whether an entry should be dropped by the message filters
+// or the filter set with SetFilter.
+func filtered(level Level, msg string, fields []any) bool {
+	filterMu.RLock()
+	fn := filter
+	suppress := suppressPrefixes
+	allow := allowPrefixes
+	suppressRes := suppressPatterns
+	allowRes := allowPatterns
+	matches := suppressFields
+	filterMu.RUnlock()
+
+	if hasAnyPrefix(msg, suppress) {
+		return true
+	}
+	if len(allow) > 0 && !hasAnyPrefix(msg, allow) {
+		return true
+	}
+	for _, re := range suppressRes {
+		if re.MatchString(msg) {
+			return true
+		}
+	}
+	for _, re := range allowRes {
+		if !re.MatchString(msg) {
+			return true
+		}
+	}
+	if len(matches) > 0 && hasMatchingField(fields, matches) {
+		return true
+	}
+
+	return fn != nil && !fn(level, msg, fields)
+}
+
+// hasMatchingField reports whether fields contains any of matches.
+func hasMatchingField(fields []any, matches []fieldMatch) bool {
+	for i := 0; i+1 < len(fields); i += 2 {
+		key, ok := fields[i].(string)
+		if !ok {
+			continue
+		}
+		for _, match := range matches {
+			if key == match.key && reflect.DeepEqual(fields[i+1], match.value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasAnyPrefix reports whether s starts with any of prefixes.
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
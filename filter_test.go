@@ -0,0 +1,68 @@
+package bark_test
+
+import (
+	"testing"
+
+	"go.dalton.dog/bark"
+)
+
+func TestSuppressWhenFieldEquals(t *testing.T) {
+	type owner struct {
+		Team string
+		ID   int
+	}
+
+	restore, capture := bark.CaptureGlobal()
+	defer restore()
+	defer bark.ClearFieldFilters()
+
+	bark.SuppressWhenFieldEquals("tenant", "load-test")
+	bark.SuppressWhenFieldEquals("shard", 3)
+	bark.SuppressWhenFieldEquals("owner", owner{Team: "infra", ID: 7})
+
+	bark.Info("string match", "tenant", "load-test")
+	bark.Info("int match", "shard", 3)
+	bark.Info("struct match", "owner", owner{Team: "infra", ID: 7})
+	bark.With("tenant", "load-test").Info("match from With")
+
+	bark.Info("other string", "tenant", "acme")
+	bark.Info("other int type", "shard", int64(3))
+	bark.Info("other struct", "owner", owner{Team: "infra", ID: 8})
+	bark.Info("value under another key", "user", "load-test")
+
+	for _, msg := range []string{"string match", "int match", "struct match", "match from With"} {
+		bark.AssertNotLogged(t, capture, bark.InfoLevel, msg)
+	}
+	for _, msg := range []string{"other string", "other int type", "other struct", "value under another key"} {
+		bark.AssertLogged(t, capture, bark.InfoLevel, msg)
+	}
+}
+
+func TestAllowRulesCombine(t *testing.T) {
+	restore, capture := bark.CaptureGlobal()
+	defer restore()
+	defer bark.ClearPrefixFilters()
+	defer bark.ClearMatchingFilters()
+
+	// Allowed prefixes combine with OR, and allowed patterns with AND, on top of them.
+	bark.AllowOnlyPrefix("db:")
+	bark.AllowOnlyPrefix("http:")
+	if err := bark.AllowOnlyMatching(`took \d+ms`); err != nil {
+		t.Fatal(err)
+	}
+	if err := bark.AllowOnlyMatching(`query|GET`); err != nil {
+		t.Fatal(err)
+	}
+
+	bark.Info("db: query took 12ms")
+	bark.Info("http: GET /users took 3ms")
+	bark.Info("db: connect took 3ms")
+	bark.Info("db: query failed")
+	bark.Info("cache: query took 1ms")
+
+	bark.AssertLogged(t, capture, bark.InfoLevel, "db: query took 12ms")
+	bark.AssertLogged(t, capture, bark.InfoLevel, "http: GET /users took 3ms")
+	for _, msg := range []string{"db: connect", "db: query failed", "cache: query"} {
+		bark.AssertNotLogged(t, capture, bark.InfoLevel, msg)
+	}
+}
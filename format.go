@@ -0,0 +1,128 @@
+package bark
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// Format selects how a sink renders entries.
+type Format int
+
+const (
+	// FormatPretty renders styled, human-readable lines. It is the default.
+	FormatPretty Format = iota
+	// FormatJSON renders one JSON object per line.
+	FormatJSON
+	// FormatLogfmt renders one logfmt line per entry.
+	FormatLogfmt
+	// FormatCloudWatch renders one JSON object per line in the shape AWS CloudWatch
+	// Logs Insights expects: "@timestamp" in ISO 8601, "level", and "message",
+	// followed by the entry's fields. The timestamp is always written, in UTC,
+	// regardless of TimeFormat.
+	FormatCloudWatch
+	// FormatGCP renders one JSON object per line as Google Cloud Logging structured
+	// logs: "time", "severity", and "message", followed by the entry's fields.
+	// Fields named trace_id and span_id become the trace and span of the entry;
+	// see SetGCPProject. Like FormatCloudWatch, it ignores TimeFormat.
+	FormatGCP
+	// FormatDatadog renders one JSON object per line in Datadog's log schema:
+	// "timestamp", "status", and "message", then "service" and "version" as set
+	// with SetDatadogService and SetDatadogVersion, followed by the entry's fields.
+	// Fields named trace_id and span_id become dd.trace_id and dd.span_id.
+	// Like FormatCloudWatch, it ignores TimeFormat.
+	FormatDatadog
+)
+
+// cloudWatchTimeFormat is the ISO 8601 layout of FormatCloudWatch timestamps.
+const cloudWatchTimeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// String returns the name of the format.
+func (f Format) String() string {
+	switch f {
+	case FormatPretty:
+		return "pretty"
+	case FormatJSON:
+		return "json"
+	case FormatLogfmt:
+		return "logfmt"
+	case FormatCloudWatch:
+		return "cloudwatch"
+	case FormatGCP:
+		return "gcp"
+	case FormatDatadog:
+		return "datadog"
+	default:
+		return "unknown"
+	}
+}
+
+// formatter returns the underlying log formatter used for f.
+func (f Format) formatter() log.Formatter {
+	switch f {
+	case FormatJSON, FormatCloudWatch, FormatGCP, FormatDatadog:
+		return log.JSONFormatter
+	case FormatLogfmt:
+		return log.LogfmtFormatter
+	default:
+		return log.TextFormatter
+	}
+}
+
+// structured reports whether f is a machine-readable format.
+func (f Format) structured() bool {
+	return f != FormatPretty
+}
+
+// enveloped reports whether f names an entry's timestamp, level, and message
+// with keys of its own, rather than the underlying formatter's.
+func (f Format) enveloped() bool {
+	return f == FormatCloudWatch || f == FormatGCP || f == FormatDatadog
+}
+
+// envelope returns fields preceded by the timestamp, level, and message of an
+// entry, under the keys f uses for them. Like structuredLevel, it reports
+// Success as Info with a success=true field.
+func (f Format) envelope(ts time.Time, level Level, msg string, fields []any) []any {
+	if level == SuccessLevel {
+		level = InfoLevel
+		fields = append(slices.Clip(fields), "success", true)
+	}
+
+	switch f {
+	case FormatGCP:
+		return gcpEnvelope(ts, level, msg, fields)
+	case FormatDatadog:
+		return datadogEnvelope(ts, level, msg, fields)
+	}
+
+	return append([]any{
+		rawKey("@timestamp"), ts.UTC().Format(cloudWatchTimeFormat),
+		rawKey("level"), strings.ToUpper(levelName(level)),
+		rawKey("message"), msg,
+	}, fields...)
+}
+
+// liftFields returns envelope followed by fields, except that fields for which
+// lift returns non-nil keyvals are replaced by those keyvals, moved into the
+// envelope. Returning an empty slice drops the field.
+func liftFields(envelope, fields []any, lift func(key string, value any) []any) []any {
+	rest := make([]any, 0, len(fields))
+	for i := 0; i < len(fields); i += 2 {
+		if i+1 == len(fields) {
+			rest = append(rest, fields[i])
+			break
+		}
+
+		if lifted := lift(fmt.Sprint(fields[i]), fields[i+1]); lifted != nil {
+			envelope = append(envelope, lifted...)
+		} else {
+			rest = append(rest, fields[i], fields[i+1])
+		}
+	}
+
+	return append(envelope, rest...)
+}
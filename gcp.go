@@ -0,0 +1,79 @@
+package bark
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Keys Google Cloud Logging reads the trace and span of an entry from.
+const (
+	gcpTraceKey = "logging.googleapis.com/trace"
+	gcpSpanKey  = "logging.googleapis.com/spanId"
+)
+
+var (
+	gcpMu      sync.RWMutex
+	gcpProject string
+)
+
+// SetGCPProject sets the Google Cloud project that FormatGCP names in trace
+// references, so that Cloud Logging links entries to Cloud Trace. Without it,
+// the trace_id field is passed on as is. Passing an empty string unsets it.
+func SetGCPProject(id string) {
+	gcpMu.Lock()
+	defer gcpMu.Unlock()
+	gcpProject = id
+}
+
+// gcpEnvelope returns fields preceded by the time, severity, and message of an
+// entry, with any trace_id and span_id fields moved to the keys Cloud Logging reads.
+func gcpEnvelope(ts time.Time, level Level, msg string, fields []any) []any {
+	envelope := []any{
+		rawKey("time"), ts.UTC().Format(time.RFC3339Nano),
+		rawKey("severity"), gcpSeverity(level),
+		rawKey("message"), msg,
+	}
+
+	return liftFields(envelope, fields, func(key string, value any) []any {
+		switch key {
+		case "trace_id":
+			return []any{rawKey(gcpTraceKey), gcpTrace(fmt.Sprint(value))}
+		case "span_id":
+			return []any{rawKey(gcpSpanKey), value}
+		}
+		return nil
+	})
+}
+
+// gcpTrace returns the trace reference for traceID in the current project.
+func gcpTrace(traceID string) string {
+	gcpMu.RLock()
+	defer gcpMu.RUnlock()
+
+	if gcpProject == "" {
+		return traceID
+	}
+	return "projects/" + gcpProject + "/traces/" + traceID
+}
+
+// gcpSeverity returns the Cloud Logging severity closest to level. Levels added
+// with RegisterLevel take the severity of the nearest built-in level below them.
+func gcpSeverity(level Level) string {
+	switch {
+	case level == PrintLevel:
+		return "DEFAULT"
+	case level >= PanicLevel:
+		return "CRITICAL"
+	case level >= ErrorLevel:
+		return "ERROR"
+	case level >= WarnLevel:
+		return "WARNING"
+	case level >= NoticeLevel:
+		return "NOTICE"
+	case level >= InfoLevel:
+		return "INFO"
+	default:
+		return "DEBUG"
+	}
+}
@@ -0,0 +1,183 @@
+package bark
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+)
+
+// levelBody is the JSON body read and written by LevelHandler.
+type levelBody struct {
+	Level string `json:"level"`
+}
+
+// LevelHandler returns an http.Handler for inspecting and changing the global level
+// at runtime, e.g. from a debug mux:
+//
+//	mux.Handle("/debug/loglevel", bark.LevelHandler())
+//
+// GET responds with the current level as {"level":"info"}. PUT and POST take a body
+// of the same form, with any name ParseLevel accepts, set the level, and respond
+// with the new level. An invalid body or level is answered with 400 Bad Request.
+func LevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut, http.MethodPost:
+			var body levelBody
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				http.Error(w, fmt.Sprintf("invalid body: %v", err), http.StatusBadRequest)
+				return
+			}
+
+			level, err := ParseLevel(body.Level)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			SetLevel(level)
+		default:
+			w.Header().Set("Allow", "GET, PUT, POST")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(levelBody{Level: levelName(Level(globalLevel.Load()))})
+	})
+}
+
+// debugPage is the state shown by DebugHandler.
+type debugPage struct {
+	Config  []debugField      `json:"config"`
+	Loggers []string          `json:"loggers"`
+	Counts  map[string]uint64 `json:"counts"`
+	Recent  []debugEntry      `json:"recent"`
+	// Recording reports whether the ring buffer is keeping recent entries.
+	Recording bool `json:"recording"`
+}
+
+// debugField is one key and value, kept in order rather than in a map.
+type debugField struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type debugEntry struct {
+	Level   string       `json:"level"`
+	Message string       `json:"msg"`
+	Fields  []debugField `json:"fields,omitempty"`
+}
+
+// DebugHandler returns an http.Handler showing bark's state at a glance, e.g. from a debug mux:
+//
+//	mux.Handle("/debug/bark", bark.DebugHandler())
+//
+// The page lists the current configuration as logged by DumpConfig, the Loggers
+// created with GetLogger, the LogCounter counts, and the most recent entries,
+// newest last. It is HTML unless the request asks for JSON, with an Accept header
+// of application/json or a format=json query parameter. Recent entries are those
+// kept by the ring buffer, so call EnableRingBuffer too to have any listed:
+//
+//	bark.EnableRingBuffer(100)
+//	mux.Handle("/debug/bark", bark.DebugHandler())
+func DebugHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		page := currentDebugPage()
+		if r.URL.Query().Get("format") == "json" || strings.Contains(r.Header.Get("Accept"), "application/json") {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(page)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := debugTemplate.Execute(w, page); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// currentDebugPage collects the state shown by DebugHandler.
+func currentDebugPage() debugPage {
+	page := debugPage{
+		Config:  debugFields(CurrentConfig().fields()),
+		Loggers: ListLoggers(),
+		Counts:  map[string]uint64{},
+
+		Recording: ringBufferEnabled(),
+	}
+
+	for level, count := range LogCounter() {
+		page.Counts[levelName(level)] = count
+	}
+
+	for _, entry := range RingBufferEntries() {
+		page.Recent = append(page.Recent, debugEntry{
+			Level:   levelName(entry.Level),
+			Message: entry.Message,
+			Fields:  debugFields(entry.Fields),
+		})
+	}
+
+	return page
+}
+
+// debugFields formats keyvals for DebugHandler.
+func debugFields(keyvals []any) []debugField {
+	var fields []debugField
+	for i := 0; i < len(keyvals); i += 2 {
+		field := debugField{Key: fmt.Sprint(keyvals[i])}
+		if i+1 < len(keyvals) {
+			field.Value = fmt.Sprint(keyvals[i+1])
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+var debugTemplate = template.Must(template.New("bark").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>bark</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 0.25em 0.75em; text-align: left; vertical-align: top; }
+td.mono { font-family: monospace; }
+</style>
+</head>
+<body>
+<h1>bark</h1>
+
+<h2>Configuration</h2>
+<table>
+{{range .Config}}<tr><th>{{.Key}}</th><td class="mono">{{.Value}}</td></tr>
+{{end}}</table>
+
+<h2>Loggers</h2>
+{{if .Loggers}}<ul>
+{{range .Loggers}}<li>{{.}}</li>
+{{end}}</ul>{{else}}<p>None.</p>{{end}}
+
+<h2>Counts</h2>
+<table>
+{{range $level, $count := .Counts}}<tr><th>{{$level}}</th><td>{{$count}}</td></tr>
+{{end}}</table>
+
+<h2>Recent entries</h2>
+{{if .Recent}}<table>
+<tr><th>Level</th><th>Message</th><th>Fields</th></tr>
+{{range .Recent}}<tr><td>{{.Level}}</td><td class="mono">{{.Message}}</td><td class="mono">{{range .Fields}}{{.Key}}={{.Value}} {{end}}</td></tr>
+{{end}}</table>{{else if .Recording}}<p>None.</p>{{else}}<p>None kept: call bark.EnableRingBuffer to keep recent entries.</p>{{end}}
+</body>
+</html>
+`))
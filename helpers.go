@@ -0,0 +1,58 @@
+package bark
+
+import "slices"
+
+// LogIf logs msg at the given level only when condition is true.
+func LogIf(condition bool, level Level, msg string, keyvals ...any) {
+	if !condition {
+		return
+	}
+
+	std.log(level, msg, keyvals...)
+}
+
+// LogIfErr logs msg at the given level only when err is non-nil,
+// attaching the error text as an "err" field.
+func LogIfErr(err error, level Level, msg string) {
+	if err == nil {
+		return
+	}
+
+	std.log(level, msg, "err", err.Error())
+}
+
+// LogAndReturn logs err's message and keyvals at the given level and returns err
+// unchanged, so logging and returning an error fits in one expression.
+// Any fields attached to err with Annotate are logged as structured fields too:
+//
+//	return bark.LogAndReturn(bark.ErrorLevel, err, "op", "connect")
+//
+// A nil err is returned without logging anything.
+func LogAndReturn(level Level, err error, keyvals ...any) error {
+	if err == nil {
+		return nil
+	}
+
+	fields := append(AnnotationFields(err), keyvals...)
+	if level >= ErrorLevel {
+		fields = append(fields, stackField(err)...)
+	}
+	std.log(level, errorMessage(err), fields...)
+
+	return err
+}
+
+// MustNil does nothing when err is nil. Otherwise it logs msg at Fatal level
+// with keyvals and an "error" field holding err's text, then terminates the program.
+// Useful for startup steps that should abort on any error.
+func MustNil(err error, msg string, keyvals ...any) {
+	if err == nil {
+		return
+	}
+
+	// Clip keyvals so the caller's slice is never written to.
+	fields := append(slices.Clip(keyvals), "error", err.Error())
+	fields = append(fields, stackField(err)...)
+	std.log(FatalLevel, msg, fields...)
+	std.exit(msg)
+}
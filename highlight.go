@@ -0,0 +1,123 @@
+package bark
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// formatArgs is the format string and values a message was formatted from by a *f method.
+type formatArgs struct {
+	format string
+	args   []any
+}
+
+// formatPart is a piece of a formatted message: literal text from the format
+// string, or the text of one formatted value.
+type formatPart struct {
+	text  string
+	value bool
+}
+
+// splitFormat formats args according to format, as fmt.Sprintf does, but returns
+// the literal text and each formatted value as separate parts. It reports false
+// for formats it doesn't handle confidently: those using explicit argument indexes
+// or * widths, those whose verbs and values don't pair up, and any whose parts
+// don't join up to exactly what fmt.Sprintf produces.
+func splitFormat(format string, args []any) ([]formatPart, bool) {
+	var parts []formatPart
+	var literal strings.Builder
+	next := 0
+
+	for i := 0; i < len(format); {
+		if format[i] != '%' {
+			literal.WriteByte(format[i])
+			i++
+			continue
+		}
+
+		if i+1 < len(format) && format[i+1] == '%' {
+			literal.WriteByte('%')
+			i += 2
+			continue
+		}
+
+		// The verb's flags, width, and precision, as in %-8.3f.
+		end := i + 1
+		for end < len(format) && strings.IndexByte("+-# 0123456789.", format[end]) >= 0 {
+			end++
+		}
+		if end == len(format) || format[end] == '*' || format[end] == '[' {
+			return nil, false
+		}
+		_, size := utf8.DecodeRuneInString(format[end:])
+		end += size
+
+		if next == len(args) {
+			return nil, false
+		}
+		if literal.Len() > 0 {
+			parts = append(parts, formatPart{text: literal.String()})
+			literal.Reset()
+		}
+		parts = append(parts, formatPart{text: fmt.Sprintf(format[i:end], args[next]), value: true})
+		next++
+		i = end
+	}
+
+	if next != len(args) {
+		return nil, false
+	}
+	if literal.Len() > 0 {
+		parts = append(parts, formatPart{text: literal.String()})
+	}
+
+	var joined strings.Builder
+	for _, part := range parts {
+		joined.WriteString(part.text)
+	}
+	if joined.String() != fmt.Sprintf(format, args...) {
+		return nil, false
+	}
+	return parts, true
+}
+
+// renderHighlighted renders msg, formatted from args, with its values in s's
+// highlight style and the rest in style, the message style of the entry's level,
+// if any; the values inherit that style too. It reports false if msg can't be
+// split into values, e.g. because a pre-hook replaced it, leaving the caller to
+// render it as usual.
+func (s *sink) renderHighlighted(style *lipgloss.Style, msg string, args *formatArgs) (string, bool) {
+	parts, ok := splitFormat(args.format, args.args)
+	if !ok {
+		return "", false
+	}
+
+	var b strings.Builder
+	for _, part := range parts {
+		b.WriteString(part.text)
+	}
+	if b.String() != msg {
+		return "", false
+	}
+
+	value := *s.highlight
+	if style != nil {
+		value = value.Inherit(*style)
+	}
+
+	b.Reset()
+	for _, part := range parts {
+		switch {
+		case part.value:
+			b.WriteString(renderMessage(s.renderer, value, part.text))
+		case style != nil:
+			b.WriteString(renderMessage(s.renderer, *style, part.text))
+		default:
+			b.WriteString(part.text)
+		}
+	}
+	return b.String(), true
+}
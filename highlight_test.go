@@ -0,0 +1,80 @@
+package bark
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		args   []any
+		want   []formatPart
+		ok     bool
+	}{
+		{
+			name:   "no verbs",
+			format: "plain text",
+			want:   []formatPart{{text: "plain text"}},
+			ok:     true,
+		},
+		{
+			name:   "literal percent",
+			format: "100%% of %d",
+			args:   []any{5},
+			want:   []formatPart{{text: "100% of "}, {text: "5", value: true}},
+			ok:     true,
+		},
+		{
+			name:   "verb at end",
+			format: "got %v",
+			args:   []any{"x"},
+			want:   []formatPart{{text: "got "}, {text: "x", value: true}},
+			ok:     true,
+		},
+		{
+			name:   "adjacent verbs with flags",
+			format: "%-4s|%05.1f",
+			args:   []any{"ab", 3.14159},
+			want:   []formatPart{{text: "ab  ", value: true}, {text: "|"}, {text: "003.1", value: true}},
+			ok:     true,
+		},
+		{
+			name:   "explicit argument index",
+			format: "%[2]d %[1]d",
+			args:   []any{1, 2},
+		},
+		{
+			name:   "star width",
+			format: "%*d",
+			args:   []any{4, 2},
+		},
+		{
+			name:   "missing arg",
+			format: "%d and %d",
+			args:   []any{1},
+		},
+		{
+			name:   "extra arg",
+			format: "%d",
+			args:   []any{1, 2},
+		},
+		{
+			name:   "trailing percent",
+			format: "50%",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := splitFormat(tt.format, tt.args)
+			if ok != tt.ok {
+				t.Fatalf("splitFormat(%q) ok = %v, want %v", tt.format, ok, tt.ok)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("splitFormat(%q) = %+v, want %+v", tt.format, got, tt.want)
+			}
+		})
+	}
+}
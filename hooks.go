@@ -0,0 +1,197 @@
+package bark
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// PreHook inspects an entry before it is written and returns the message and
+// keyvals to write in its place, e.g. to enrich entries with a trace ID.
+type PreHook func(level Level, msg string, keyvals []any) (string, []any)
+
+// PostHook observes an entry after it has been written, e.g. to count
+// entries or raise alerts. It cannot change the entry.
+type PostHook func(level Level, msg string, keyvals []any)
+
+// defaultPostHookTimeout is how long a log call waits for each PostHook.
+const defaultPostHookTimeout = 10 * time.Millisecond
+
+// postHook is a registered PostHook and whether it runs inline in log calls.
+// ring marks the hook EnableRingBuffer installs, so DisableRingBuffer can remove it.
+type postHook struct {
+	fn     PostHook
+	inline bool
+	ring   bool
+}
+
+var (
+	hooksMu         sync.RWMutex
+	preHooks        []PreHook
+	postHooks       []postHook
+	postHookTimeout = defaultPostHookTimeout
+	fatalHooks      []func(msg string)
+	fatalExitCode   = 1
+)
+
+// AddPreHook registers fn to run on every entry that passes level filtering,
+// before it is written. Hooks run in registration order, each receiving the
+// message and keyvals returned by the previous one.
+func AddPreHook(fn PreHook) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	preHooks = append(preHooks, fn)
+}
+
+// AddPostHook registers fn to run after every entry has been written to all sinks.
+// Hooks run in registration order. A log call waits at most the post hook
+// timeout (10ms by default, see SetPostHookTimeout) for each hook; a hook that
+// takes longer keeps running in the background. Panics in a hook are recovered.
+func AddPostHook(fn PostHook) {
+	addPostHook(postHook{fn: fn})
+}
+
+// AddInlinePostHook registers fn like AddPostHook, but runs it directly in the
+// log call, without a goroutine or timeout, for hooks that are always quick,
+// such as counters. A slow inline hook delays every log call.
+func AddInlinePostHook(fn PostHook) {
+	addPostHook(postHook{fn: fn, inline: true})
+}
+
+// addPostHook registers hook.
+func addPostHook(hook postHook) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	postHooks = append(postHooks, hook)
+}
+
+// removePostHooks unregisters every post hook for which remove returns true.
+func removePostHooks(remove func(postHook) bool) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	// Copy rather than delete in place, since runPostHooks iterates over snapshots.
+	postHooks = slices.DeleteFunc(slices.Clone(postHooks), remove)
+}
+
+// HasPostHooks reports whether any PostHook is registered, for integrations that
+// escalate entries only when a hook is there to act on them. The hook installed
+// by EnableRingBuffer doesn't count.
+func HasPostHooks() bool {
+	hooksMu.RLock()
+	defer hooksMu.RUnlock()
+	return slices.ContainsFunc(postHooks, func(hook postHook) bool { return !hook.ring })
+}
+
+// SetPostHookTimeout sets how long a log call waits for each PostHook
+// before leaving it to finish in the background. A timeout of 0 or less
+// runs every hook inline, as if added with AddInlinePostHook.
+func SetPostHookTimeout(d time.Duration) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	postHookTimeout = d
+}
+
+// AddFatalHook registers fn to run when Fatal or Fatalf is called, after the
+// entry has been written and before the program exits, since os.Exit skips
+// deferred functions. Hooks run synchronously in registration order and
+// receive the fatal message.
+func AddFatalHook(fn func(msg string)) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	fatalHooks = append(fatalHooks, fn)
+}
+
+// SetFatalExitCode sets the status Fatal and Fatalf exit with, so calling scripts
+// can tell a logged fatal apart from a crash. The default is 1.
+// Zero is rejected with an error, since it conventionally means success.
+func SetFatalExitCode(code int) error {
+	if code == 0 {
+		return fmt.Errorf("fatal exit code cannot be 0, which means success")
+	}
+
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	fatalExitCode = code
+
+	return nil
+}
+
+// RemoveAllHooks unregisters every hook and restores the default post hook timeout.
+// The ring buffer keeps recording; use DisableRingBuffer to stop it.
+func RemoveAllHooks() {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	preHooks = nil
+	postHooks = slices.DeleteFunc(slices.Clone(postHooks), func(hook postHook) bool { return !hook.ring })
+	fatalHooks = nil
+	postHookTimeout = defaultPostHookTimeout
+}
+
+// runPreHooks passes msg and keyvals through every registered PreHook.
+func runPreHooks(level Level, msg string, keyvals []any) (string, []any) {
+	hooksMu.RLock()
+	hooks := preHooks
+	hooksMu.RUnlock()
+
+	for _, hook := range hooks {
+		msg, keyvals = hook(level, msg, keyvals)
+	}
+
+	return msg, keyvals
+}
+
+// runPostHooks calls every registered PostHook, waiting up to the post hook
+// timeout for each before moving on, or calling it directly if it runs inline.
+func runPostHooks(level Level, msg string, keyvals []any) {
+	hooksMu.RLock()
+	hooks := postHooks
+	timeout := postHookTimeout
+	hooksMu.RUnlock()
+
+	for _, hook := range hooks {
+		if hook.inline || timeout <= 0 {
+			callPostHook(hook.fn, level, msg, keyvals)
+			continue
+		}
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			callPostHook(hook.fn, level, msg, keyvals)
+		}()
+
+		timer := time.NewTimer(timeout)
+		select {
+		case <-done:
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+}
+
+// callPostHook calls fn, recovering from any panic.
+func callPostHook(fn PostHook, level Level, msg string, keyvals []any) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(os.Stderr, "bark: post hook panicked: %v\n", r)
+		}
+	}()
+	fn(level, msg, keyvals)
+}
+
+// runFatalHooks calls every registered fatal hook with msg
+// and returns the status the program should exit with.
+func runFatalHooks(msg string) int {
+	hooksMu.RLock()
+	hooks := fatalHooks
+	code := fatalExitCode
+	hooksMu.RUnlock()
+
+	for _, hook := range hooks {
+		hook(msg)
+	}
+
+	return code
+}
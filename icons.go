@@ -0,0 +1,107 @@
+package bark
+
+import (
+	"os"
+	"strings"
+)
+
+// IconMode selects whether level badges in pretty output start with an icon.
+type IconMode int
+
+const (
+	// IconsOff draws badges without icons. It is the default.
+	IconsOff IconMode = iota
+	// IconsAuto uses Nerd Font icons where the terminal looks capable of showing
+	// them, and ASCII ones elsewhere. See IconsNerdFont.
+	IconsAuto
+	// IconsNerdFont uses Nerd Font glyphs, such as  for Info and  for Warn.
+	// Automatic detection can't see the font, so IconsAuto only picks them when
+	// the locale is UTF-8 and either BARK_NERD_FONT is set to anything but 0
+	// or the terminal is WezTerm, which bundles the glyphs.
+	IconsNerdFont
+	// IconsASCII uses plain ASCII icons, such as [i] for Info and [!] for Warn.
+	IconsASCII
+)
+
+// String returns the name of the icon mode.
+func (m IconMode) String() string {
+	switch m {
+	case IconsOff:
+		return "off"
+	case IconsAuto:
+		return "auto"
+	case IconsNerdFont:
+		return "nerdfont"
+	case IconsASCII:
+		return "ascii"
+	default:
+		return "unknown"
+	}
+}
+
+// nerdFontIcons and asciiIcons are the icons of bark's own levels. Each set is
+// of a single width, so badges still line up: Nerd Font glyphs take one cell.
+var (
+	nerdFontIcons = map[Level]string{
+		TraceLevel:   "", // nf-fa-list
+		DebugLevel:   "", // nf-fa-bug
+		InfoLevel:    "", // nf-fa-info_circle
+		SuccessLevel: "", // nf-fa-check_circle
+		NoticeLevel:  "", // nf-fa-bell
+		WarnLevel:    "", // nf-fa-warning
+		ErrorLevel:   "", // nf-fa-times_circle
+		PanicLevel:   "", // nf-fa-bomb
+		FatalLevel:   "", // nf-fa-ban
+	}
+	asciiIcons = map[Level]string{
+		TraceLevel:   "[t]",
+		DebugLevel:   "[d]",
+		InfoLevel:    "[i]",
+		SuccessLevel: "[+]",
+		NoticeLevel:  "[*]",
+		WarnLevel:    "[!]",
+		ErrorLevel:   "[x]",
+		PanicLevel:   "[p]",
+		FatalLevel:   "[X]",
+	}
+)
+
+// icons returns the icon set m selects, or nil for none.
+func (m IconMode) icons() map[Level]string {
+	switch m {
+	case IconsNerdFont:
+		return nerdFontIcons
+	case IconsASCII:
+		return asciiIcons
+	case IconsAuto:
+		if nerdFontCapable() {
+			return nerdFontIcons
+		}
+		return asciiIcons
+	default:
+		return nil
+	}
+}
+
+// nerdFontCapable guesses from the environment whether the terminal shows Nerd Font glyphs.
+func nerdFontCapable() bool {
+	if !utf8Locale() {
+		return false
+	}
+	if v := os.Getenv("BARK_NERD_FONT"); v != "" {
+		return v != "0"
+	}
+	return os.Getenv("TERM_PROGRAM") == "WezTerm"
+}
+
+// utf8Locale reports whether the locale, from the first of LC_ALL, LC_CTYPE,
+// and LANG that is set, uses UTF-8.
+func utf8Locale() bool {
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if locale := os.Getenv(name); locale != "" {
+			locale = strings.ToLower(locale)
+			return strings.Contains(locale, "utf-8") || strings.Contains(locale, "utf8")
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+// Package batch queues the items written to bark's network sinks, such as
+// barkloki's and barksplunk's, and sends them in batches from a goroutine.
+package batch
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrClosed is returned by Add once the Batcher is closed.
+var ErrClosed = errors.New("batcher is closed")
+
+// Batcher collects items and hands them to its send function in batches of at
+// most its size, whenever a batch fills up, its interval passes, or it is
+// flushed. Send errors are kept for the next Flush or Close, so Add never
+// waits on sending.
+type Batcher[T any] struct {
+	size int
+	send func([]T) error
+
+	// sendMu serializes pushes, so batches are sent in order, and Flush
+	// returns only once any batch already being sent is done.
+	sendMu sync.Mutex
+
+	mu      sync.Mutex
+	pending []T
+	err     error
+	closed  bool
+
+	flushNow chan struct{}
+	done     chan struct{}
+	stopped  chan struct{}
+}
+
+// New returns a Batcher passing batches of at most size items to send, at
+// least every interval, and starts its goroutine, which runs until Close.
+func New[T any](size int, interval time.Duration, send func([]T) error) *Batcher[T] {
+	b := &Batcher[T]{
+		size:     size,
+		send:     send,
+		flushNow: make(chan struct{}, 1),
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	go b.run(interval)
+
+	return b
+}
+
+// Add queues item, waking the goroutine if a batch is full.
+// It returns ErrClosed if b is closed.
+func (b *Batcher[T]) Add(item T) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return ErrClosed
+	}
+
+	b.pending = append(b.pending, item)
+	if len(b.pending) >= b.size {
+		select {
+		case b.flushNow <- struct{}{}:
+		default:
+		}
+	}
+
+	return nil
+}
+
+// Flush sends every pending item and returns any error from sends since the last Flush.
+func (b *Batcher[T]) Flush() error {
+	b.push()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	err := b.err
+	b.err = nil
+	return err
+}
+
+// Close stops b's goroutine, then sends every pending item like Flush.
+// Closing b again does nothing.
+func (b *Batcher[T]) Close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	b.mu.Unlock()
+
+	close(b.done)
+	<-b.stopped
+
+	return b.Flush()
+}
+
+// run sends batches until b is closed.
+func (b *Batcher[T]) run(interval time.Duration) {
+	defer close(b.stopped)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-b.flushNow:
+		case <-b.done:
+			return
+		}
+		b.push()
+	}
+}
+
+// push sends the pending items, in batches of at most b's size, recording any error.
+func (b *Batcher[T]) push() {
+	b.sendMu.Lock()
+	defer b.sendMu.Unlock()
+
+	b.mu.Lock()
+	pending := b.pending
+	b.pending = nil
+	b.mu.Unlock()
+
+	for len(pending) > 0 {
+		n := min(len(pending), b.size)
+		if err := b.send(pending[:n]); err != nil {
+			b.mu.Lock()
+			b.err = errors.Join(b.err, err)
+			b.mu.Unlock()
+		}
+		pending = pending[n:]
+	}
+}
@@ -0,0 +1,105 @@
+package bark
+
+import "fmt"
+
+// Config describes the configuration bark is actually using.
+type Config struct {
+	// Options holds the resolved values of the last Init, defaults included.
+	Options BarkOptions
+	// Level is the global level.
+	Level Level
+	// Sinks describes every sink entries are written to, primary first.
+	Sinks []SinkConfig
+}
+
+// SinkConfig describes a single sink.
+type SinkConfig struct {
+	// Kind is "stderr", "stdout", "file", "capture", "syslog", or "writer".
+	Kind string
+	// Format is how the sink renders entries.
+	Format Format
+	// Level is the minimum level the sink writes.
+	Level Level
+	// Color reports whether the sink renders colors.
+	Color bool
+}
+
+// CurrentConfig returns the configuration bark is using right now, including
+// changes made since Init such as SetLevel or AddWriterLogger.
+// Useful for answering "why is my output not colored" or "why is Debug hidden".
+func CurrentConfig() Config {
+	current := currentSinks()
+
+	sinksMu.RLock()
+	cfg := Config{
+		Options: activeConfig.options(),
+		Level:   Level(globalLevel.Load()),
+	}
+	sinksMu.RUnlock()
+
+	for _, s := range current {
+		level := cfg.Level
+		if s.quieted(level) {
+			level = ErrorLevel
+		}
+
+		s.mu.Lock()
+		cfg.Sinks = append(cfg.Sinks, SinkConfig{
+			Kind:   s.kind,
+			Format: s.format,
+			Level:  level,
+			Color:  s.color,
+		})
+		s.mu.Unlock()
+	}
+
+	return cfg
+}
+
+// DumpConfig logs the current configuration at Debug level.
+func DumpConfig() {
+	std.log(DebugLevel, "bark config", CurrentConfig().fields()...)
+}
+
+// fields returns cfg as keyvals, as logged by DumpConfig and shown by DebugHandler.
+func (cfg Config) fields() []any {
+	keyvals := []any{
+		"global_level", levelName(cfg.Level),
+		"format", cfg.Options.OutputFormat,
+		"time_format", cfg.Options.TimeFormat,
+		"info_hex", cfg.Options.InfoHex,
+		"warn_hex", cfg.Options.WarnHex,
+		"error_hex", cfg.Options.ErrorHex,
+		"debug_hex", cfg.Options.DebugHex,
+		"trace_hex", cfg.Options.TraceHex,
+		"success_hex", cfg.Options.SuccessHex,
+		"notice_hex", cfg.Options.NoticeHex,
+		"panic_hex", cfg.Options.PanicHex,
+		"report_caller", cfg.Options.ReportCaller,
+		"level_env", cfg.Options.LevelEnv,
+		"quiet", cfg.Options.Quiet,
+		"color", cfg.Options.Color,
+		"color_profile", cfg.Options.ForceColorProfile,
+		"badge_style", cfg.Options.BadgeStyle,
+		"badge_width", cfg.Options.BadgeWidth,
+		"badge_align", cfg.Options.BadgeAlign,
+		"icons", cfg.Options.Icons,
+		"compact", cfg.Options.Compact,
+		"fatal_box", cfg.Options.FatalBox,
+		"highlight_args", cfg.Options.HighlightArgs,
+		"truncate_to_width", cfg.Options.TruncateToWidth,
+		"key_hex", cfg.Options.KeyHex,
+		"value_hex", cfg.Options.ValueHex,
+		"timestamp_hex", cfg.Options.TimestampHex,
+		"hide_key_separator", cfg.Options.HideKeySeparator,
+		"prefix_hex", cfg.Options.PrefixHex,
+		"prefix_separator", cfg.Options.PrefixSeparator,
+		"section_hex", cfg.Options.SectionHex,
+		"sinks", len(cfg.Sinks),
+	}
+	for i, s := range cfg.Sinks {
+		keyvals = append(keyvals, fmt.Sprintf("sink%d", i), fmt.Sprintf("%s %s level=%s color=%t", s.Kind, s.Format, levelName(s.Level), s.Color))
+	}
+
+	return keyvals
+}
@@ -0,0 +1,38 @@
+package bark
+
+import "fmt"
+
+// Lazy is a field value computed only when an entry is actually emitted,
+// for context that is expensive to build:
+//
+//	bark.Debug("loaded", "config", bark.Lazy(func() any { return cfg.Dump() }))
+//
+// Plain func() any values are treated the same way. Lazy values may be
+// passed anywhere keyvals are accepted, including With. Filters see them
+// unresolved, so entries they drop never call the function.
+// If the function panics, the value is replaced by an error describing the panic.
+type Lazy func() any
+
+// resolveLazy replaces every Lazy or func() any value in keyvals with its result.
+// keyvals is modified in place.
+func resolveLazy(keyvals []any) []any {
+	for i := 1; i < len(keyvals); i += 2 {
+		switch fn := keyvals[i].(type) {
+		case Lazy:
+			keyvals[i] = callLazy(fn)
+		case func() any:
+			keyvals[i] = callLazy(fn)
+		}
+	}
+	return keyvals
+}
+
+// callLazy calls fn, converting a panic into an error value.
+func callLazy(fn func() any) (value any) {
+	defer func() {
+		if r := recover(); r != nil {
+			value = fmt.Errorf("lazy value panicked: %v", r)
+		}
+	}()
+	return fn()
+}
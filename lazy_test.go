@@ -0,0 +1,53 @@
+package bark_test
+
+import (
+	"strings"
+	"testing"
+
+	"go.dalton.dog/bark"
+)
+
+func TestLazyResolvedOnlyForEmittedEntries(t *testing.T) {
+	restore, capture := bark.CaptureGlobal()
+	defer restore()
+	defer bark.ClearPrefixFilters()
+
+	calls := 0
+	value := bark.Lazy(func() any {
+		calls++
+		return "expensive"
+	})
+
+	bark.SuppressPrefix("noisy")
+	bark.Trace("below the level", "value", value)
+	bark.Info("noisy: filtered", "value", value)
+	if calls != 0 {
+		t.Fatalf("Lazy called %d times for dropped entries, want 0", calls)
+	}
+
+	bark.Info("emitted", "value", value)
+	if calls != 1 {
+		t.Fatalf("Lazy called %d times for one emitted entry, want 1", calls)
+	}
+
+	entries := capture.Entries()
+	if len(entries) != 1 || len(entries[0].Fields) != 2 || entries[0].Fields[1] != "expensive" {
+		t.Errorf("entries = %+v, want one with value=expensive", entries)
+	}
+}
+
+func TestLazyPanicBecomesError(t *testing.T) {
+	restore, capture := bark.CaptureGlobal()
+	defer restore()
+
+	bark.Info("emitted", "value", func() any { panic("out of cheese") })
+
+	entries := capture.Entries()
+	if len(entries) != 1 || len(entries[0].Fields) != 2 {
+		t.Fatalf("entries = %+v, want one with a value field", entries)
+	}
+	err, ok := entries[0].Fields[1].(error)
+	if !ok || !strings.Contains(err.Error(), "out of cheese") {
+		t.Errorf("value = %#v, want an error describing the panic", entries[0].Fields[1])
+	}
+}
@@ -0,0 +1,295 @@
+package bark
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/charmbracelet/log"
+)
+
+// Level is a logging level. It is the same type used by the underlying
+// charmbracelet/log package, so the two can be used interchangeably.
+type Level = log.Level
+
+// The levels bark understands, from least to most severe.
+const (
+	TraceLevel = log.Level(-8)
+	DebugLevel = log.DebugLevel
+	InfoLevel  = log.InfoLevel
+	// SuccessLevel marks positive completion messages. It filters like Info, so
+	// SetLevel and SetMaxLevel treat it as Info, and structured formats report
+	// it as "info" with a success=true field.
+	SuccessLevel = log.Level(1)
+	// NoticeLevel is for things an operator should read although nothing is wrong,
+	// such as deprecations or fallback behavior. It sorts between Info and Warn.
+	NoticeLevel = log.Level(2)
+	WarnLevel   = log.WarnLevel
+	ErrorLevel  = log.ErrorLevel
+	// PanicLevel is for entries written by Panic and Panicf, between Error and Fatal.
+	PanicLevel = log.Level(10)
+	FatalLevel = log.FatalLevel
+	// PrintLevel is the level of entries written by Print and Printf. It is above
+	// every other level, as those entries are the program's output rather than logs.
+	PrintLevel = log.Level(math.MaxInt32)
+)
+
+// customLevelNames names the levels bark defines on top of charmbracelet/log's,
+// which it doesn't know how to name itself.
+var customLevelNames = map[Level]string{
+	TraceLevel:   "trace",
+	SuccessLevel: "success",
+	NoticeLevel:  "notice",
+	PanicLevel:   "panic",
+	PrintLevel:   "print",
+}
+
+// LevelName returns the lowercase name of level, such as "warn", as understood by
+// ParseLevel, including the names of levels added with RegisterLevel.
+// It returns "" for a level without a name.
+func LevelName(level Level) string {
+	return levelName(level)
+}
+
+// levelName returns the lowercase name of level.
+func levelName(level Level) string {
+	if name, ok := customLevelNames[level]; ok {
+		return name
+	}
+	if name, ok := registeredLevelName(level); ok {
+		return name
+	}
+	return level.String()
+}
+
+// levelAliases are alternative spellings accepted by ParseLevel.
+var levelAliases = map[string]Level{
+	"warning": WarnLevel,
+	"err":     ErrorLevel,
+}
+
+// ParseLevel converts a level name such as "debug" into a Level. Names are
+// case-insensitive and include bark's own levels, levels added with
+// RegisterLevel, and the aliases "warning" and "err".
+func ParseLevel(name string) (Level, error) {
+	name = strings.TrimSpace(name)
+
+	for level, levelName := range customLevelNames {
+		if strings.EqualFold(name, levelName) {
+			return level, nil
+		}
+	}
+	if level, ok := levelAliases[strings.ToLower(name)]; ok {
+		return level, nil
+	}
+	if level, ok := registeredLevelByName(name); ok {
+		return level, nil
+	}
+
+	level, err := log.ParseLevel(name)
+	if err != nil {
+		return 0, fmt.Errorf("invalid level %q", name)
+	}
+	return level, nil
+}
+
+// passAllLevel is set on every underlying logger so that bark,
+// not charmbracelet/log, decides which entries are filtered.
+const passAllLevel = log.Level(math.MinInt)
+
+// globalLevel is the threshold for every Logger without its own level.
+// Its zero value is InfoLevel.
+var globalLevel atomic.Int64
+
+// levelSet records whether the global level has been set explicitly,
+// so that auto-initialization doesn't override it from the environment.
+var levelSet atomic.Bool
+
+// maxLevel, when set, is the most severe level that is emitted.
+var maxLevel levelVar
+
+var (
+	// minLevel is the least severe level any Logger can emit outside debug scopes,
+	// given the global level, level rules, package levels, and Logger levels,
+	// so Enabled can reject entries below it without resolving a threshold.
+	// Its zero value is InfoLevel, matching globalLevel.
+	minLevel   atomic.Int64
+	minLevelMu sync.Mutex
+
+	// loggerMinLevel, once set, is the least severe level set on any Logger
+	// with its SetLevel method since the last Reset.
+	loggerMinLevel levelVar
+)
+
+// updateMinLevel recomputes minLevel. It must be called whenever the global
+// level, level rules, or package levels change.
+func updateMinLevel() {
+	minLevelMu.Lock()
+	defer minLevelMu.Unlock()
+
+	lowest := Level(globalLevel.Load())
+	if level, ok := loggerMinLevel.get(); ok {
+		lowest = min(lowest, level)
+	}
+
+	rulesMu.RLock()
+	for _, rule := range levelRules {
+		lowest = min(lowest, rule.level)
+	}
+	rulesMu.RUnlock()
+
+	packageRulesMu.RLock()
+	for _, rule := range packageRules {
+		lowest = min(lowest, rule.level)
+	}
+	packageRulesMu.RUnlock()
+
+	minLevel.Store(int64(lowest))
+}
+
+// lowerMinLevel records that a Logger's own level was set to level.
+func lowerMinLevel(level Level) {
+	minLevelMu.Lock()
+	if current, ok := loggerMinLevel.get(); !ok || level < current {
+		loggerMinLevel.set(level)
+	}
+	if level < Level(minLevel.Load()) {
+		minLevel.Store(int64(level))
+	}
+	minLevelMu.Unlock()
+}
+
+// SetMinLevel sets the least severe level that is emitted, like SetLevel,
+// but returns an error instead if level is above the maximum set with SetMaxLevel.
+func SetMinLevel(level Level) error {
+	if highest, ok := maxLevel.get(); ok && level > highest {
+		return fmt.Errorf("SetMinLevel: %s is above the maximum level %s", levelName(level), levelName(highest))
+	}
+	SetLevel(level)
+	return nil
+}
+
+// SetMaxLevel sets the most severe level that is emitted, so that together with
+// SetMinLevel only a window of levels is shown, e.g. Warn and Error but not Fatal.
+// Entries above it are dropped by every Logger, whatever its own level.
+// It returns an error if level is below the global level set with SetMinLevel
+// or SetLevel. Reset removes the maximum.
+func SetMaxLevel(level Level) error {
+	if lowest := Level(globalLevel.Load()); level < lowest {
+		return fmt.Errorf("SetMaxLevel: %s is below the minimum level %s", levelName(level), levelName(lowest))
+	}
+	maxLevel.set(level)
+	return nil
+}
+
+// priority returns the level an entry at level is filtered as: Info for Success,
+// which filters like Info, and level itself for every other level.
+func priority(level Level) Level {
+	if level == SuccessLevel {
+		return InfoLevel
+	}
+	return level
+}
+
+// aboveMaxLevel reports whether level is above the maximum set with SetMaxLevel.
+func aboveMaxLevel(level Level) bool {
+	highest, ok := maxLevel.get()
+	return ok && level > highest
+}
+
+// levelVar is a Level that can be shared between a Logger and its children
+// and changed atomically. A level set explicitly takes precedence over one set
+// by a level rule; a levelVar with neither defers to globalLevel.
+type levelVar struct {
+	level atomic.Int64
+	isSet atomic.Bool
+
+	ruleLevel atomic.Int64
+	ruleSet   atomic.Bool
+}
+
+// set makes v override the global level.
+func (v *levelVar) set(level Level) {
+	v.level.Store(int64(level))
+	v.isSet.Store(true)
+}
+
+// unset makes v defer to the global level again.
+func (v *levelVar) unset() {
+	v.isSet.Store(false)
+}
+
+// setRule sets the level v takes from a level rule when it has none set explicitly.
+func (v *levelVar) setRule(level Level) {
+	v.ruleLevel.Store(int64(level))
+	v.ruleSet.Store(true)
+}
+
+// unsetRule removes the level set by setRule, leaving any explicit level in place.
+func (v *levelVar) unsetRule() {
+	v.ruleSet.Store(false)
+}
+
+// get returns v's level, explicit or from a rule, and whether it has one.
+func (v *levelVar) get() (Level, bool) {
+	switch {
+	case v == nil:
+		return 0, false
+	case v.isSet.Load():
+		return Level(v.level.Load()), true
+	case v.ruleSet.Load():
+		return Level(v.ruleLevel.Load()), true
+	}
+	return 0, false
+}
+
+// Enabled reports whether the package-level functions would emit an entry at level,
+// so call sites can skip building expensive messages that would be dropped:
+//
+//	if bark.DebugEnabled() {
+//		bark.Debug(dumpState())
+//	}
+//
+// It first compares level with a cached minimum across every Logger, updated
+// whenever levels, rules, or sinks change, so most disabled levels are rejected
+// with a single atomic load; filters and hooks may still drop an entry it reports as enabled.
+func Enabled(level Level) bool {
+	return std.enabled(level, 1)
+}
+
+// DebugEnabled reports whether the package-level functions would emit Debug entries.
+func DebugEnabled() bool {
+	return std.enabled(DebugLevel, 1)
+}
+
+// Enabled reports whether l would emit an entry at level. See the package-level Enabled.
+func (l *Logger) Enabled(level Level) bool {
+	return l.enabled(level, 1)
+}
+
+// enabled implements Enabled. skip is the number of frames from enabled's caller
+// to the code calling into bark, as for runtime.Caller, to resolve its package.
+func (l *Logger) enabled(level Level, skip int) bool {
+	level = priority(level)
+	if aboveMaxLevel(level) {
+		return false
+	}
+	if level >= Level(minLevel.Load()) && level >= l.threshold(skip+1) {
+		return true
+	}
+	// Looking up the goroutine is left for last, and only happens while a debug scope is active.
+	return inDebugScope(level)
+}
+
+// threshold returns the minimum level l will emit. skip is as for enabled.
+func (l *Logger) threshold(skip int) Level {
+	if level, ok := l.level.get(); ok {
+		return level
+	}
+	if level, ok := packageLevel(skip + 1); ok {
+		return level
+	}
+	return Level(globalLevel.Load())
+}
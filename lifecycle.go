@@ -0,0 +1,163 @@
+package bark
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sync"
+)
+
+// Flusher is implemented by sink writers that buffer output,
+// such as *bufio.Writer or an asynchronous network writer.
+type Flusher interface {
+	Flush() error
+}
+
+// Flush flushes every sink whose writer implements Flusher, so that everything
+// logged so far has reached its destination, and returns their errors joined.
+// Sinks that write synchronously are skipped, making Flush cheap to call
+// before os.Exit or at the end of a test. Fatal, Fatalf, Panic, and Panicf
+// flush the sinks of the Logger they are called on automatically.
+func Flush() error {
+	sinksMu.RLock()
+	active := sinks
+	sinksMu.RUnlock()
+
+	return flushSinks(active)
+}
+
+// Reset closes any closable sinks and returns bark to its pre-Init state:
+// the named Logger registry, level and package rules, application metadata,
+// GCP project, Datadog service and version, baggage fields, scopes, LogOnce
+// keys, hooks, filters, fatal exit code, default options, injected clock, quiet
+// mode, verbosity ladder, LogCounter and BytesWritten counts, and global and
+// maximum levels are all reset, and the ring buffer is disabled, discarding
+// the entries it kept. The next log call auto-initializes with the defaults
+// unless Init is called first.
+//
+// A few things outlive Reset, since they belong to code that is still running
+// or can't be undone: levels added with RegisterLevel, debug scopes entered with
+// WithDebugScope or EnterDebugScope, which last until they end, and the signal
+// handling set up by EnableSignalLevelControl, until its cancel function is
+// called, and by RegisterSignalHandler and RegisterRotationSignal.
+//
+// Reset is safe to call while other goroutines are logging; their entries
+// either reach the old sinks or go to the new default configuration.
+// The standard output and error streams are never closed.
+func Reset() error {
+	detached := detachSinks()
+
+	registryMu.Lock()
+	registry = map[string]*Logger{}
+	registryMu.Unlock()
+
+	ClearLevelRules()
+	ClearPackageLevels()
+	ClearBaggageFields()
+	clearScopes()
+	SetAppName("")
+	SetVersion("")
+	SetEnvironment("")
+	SetGCPProject("")
+	SetDatadogService("")
+	SetDatadogVersion("")
+	ResetAllOnce()
+	RemoveAllHooks()
+	ClearFilter()
+	ClearPrefixFilters()
+	ClearMatchingFilters()
+	ClearFieldFilters()
+	SetFatalExitCode(1)
+	SetDefaultOptions(BarkOptions{})
+	InjectClock(nil)
+	globalLevel.Store(int64(InfoLevel))
+	levelSet.Store(false)
+	loggerMinLevel.unset()
+	updateMinLevel()
+	maxLevel.unset()
+	SetQuiet(false)
+	SetVerbosityLadder(nil)
+	ResetCounters()
+	ResetByteCounters()
+	DisableRingBuffer()
+
+	return closeSinks(detached)
+}
+
+// Shutdown flushes and then closes every sink, giving up when ctx is done.
+// It returns ctx's error if the deadline passes before the sinks finish,
+// or any error reported while flushing or closing them.
+// Like Reset, log calls made afterwards auto-initialize with the defaults.
+func Shutdown(ctx context.Context) error {
+	detached := detachSinks()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- errors.Join(flushSinks(detached), closeSinks(detached))
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// detachSinks removes every sink from use and re-arms auto-initialization,
+// returning the removed sinks.
+func detachSinks() []*sink {
+	sinksMu.Lock()
+	defer sinksMu.Unlock()
+
+	detached := sinks
+	sinks = nil
+	autoInit = &sync.Once{}
+
+	return detached
+}
+
+// flushSinks flushes every sink whose writer buffers output.
+func flushSinks(detached []*sink) error {
+	var errs []error
+	for _, s := range detached {
+		if f, ok := s.writer().(Flusher); ok {
+			errs = append(errs, f.Flush())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+var (
+	exitMu sync.RWMutex
+	// fatalExit ends the program after a Fatal entry. MockFatal replaces it.
+	fatalExit = func(_ string, code int) { os.Exit(code) }
+)
+
+// exit runs the fatal hooks, flushes l's sinks, and terminates the program
+// with the fatal exit code after a Fatal entry with the given message.
+func (l *Logger) exit(msg string) {
+	code := runFatalHooks(msg)
+	flushSinks(l.currentSinks())
+
+	exitMu.RLock()
+	exitFn := fatalExit
+	exitMu.RUnlock()
+	exitFn(msg, code)
+}
+
+// closeSinks closes every sink whose writer can be closed, other than the standard streams.
+func closeSinks(detached []*sink) error {
+	var errs []error
+	for _, s := range detached {
+		w := s.writer()
+		if w == os.Stdout || w == os.Stderr {
+			continue
+		}
+		if c, ok := w.(io.Closer); ok {
+			errs = append(errs, c.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
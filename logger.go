@@ -0,0 +1,303 @@
+package bark
+
+import (
+	"fmt"
+	"io"
+	"slices"
+)
+
+// Logger is a handle onto the configured loggers that attaches its own set of
+// fields to every entry. The package-level functions use a default Logger with
+// no fields; child Loggers are created with With and WithPrefix, named ones with GetLogger,
+// and independently configurable copies with Clone.
+type Logger struct {
+	// prefix holds the segments of the prefix, outermost first.
+	prefix []string
+	fields []any
+	level  *levelVar
+
+	// sinks, when non-nil, replaces the sinks configured by Init.
+	sinks []*sink
+}
+
+// std is the default Logger used by the package-level functions.
+var std = &Logger{level: &levelVar{}}
+
+// New returns a Logger with a sink of its own, configured from opts like Init
+// but independent of it: later Init calls don't affect the Logger, and the
+// Logger doesn't affect the package-level functions. Its level follows the
+// global level until set with SetLevel. An invalid option returns an error.
+func New(opts ...Option) (*Logger, error) {
+	cfg, err := newConfig(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Logger{level: &levelVar{}, sinks: []*sink{newSink(cfg.output, cfg)}}, nil
+}
+
+// With returns a child of the default Logger that attaches keyvals to every entry.
+func With(keyvals ...any) *Logger {
+	return std.With(keyvals...)
+}
+
+// With returns a child Logger that attaches keyvals to every entry,
+// in addition to any fields already carried by l. The child shares l's level.
+func (l *Logger) With(keyvals ...any) *Logger {
+	fields := make([]any, 0, len(l.fields)+len(keyvals))
+	fields = append(fields, l.fields...)
+	fields = append(fields, keyvals...)
+
+	return &Logger{prefix: l.prefix, fields: fields, level: l.level, sinks: l.sinks}
+}
+
+// WithPrefix returns a child of the default Logger that prefixes every entry with prefix.
+func WithPrefix(prefix string) *Logger {
+	return std.WithPrefix(prefix)
+}
+
+// WithPrefix returns a child Logger that adds prefix to l's own, if any,
+// so that nested prefixes such as those of GetLogger("http").WithPrefix("auth")
+// render as separate segments, styled as set with WithPrefixStyle.
+// The child shares l's level and fields.
+func (l *Logger) WithPrefix(prefix string) *Logger {
+	return &Logger{prefix: append(slices.Clip(l.prefix), prefix), fields: l.fields, level: l.level, sinks: l.sinks}
+}
+
+// Clone returns an independent copy of the default Logger. See Logger.Clone.
+func Clone() *Logger {
+	return std.Clone()
+}
+
+// Clone returns a deep copy of l with its own level, prefix, fields, and sinks.
+// The sinks are copied as configured at the time of the call, styles included,
+// so changing the clone's level or output (or calling Init again) leaves l unaffected.
+func (l *Logger) Clone() *Logger {
+	clone := &Logger{
+		prefix: slices.Clone(l.prefix),
+		fields: append([]any(nil), l.fields...),
+		level:  &levelVar{},
+	}
+
+	if level, ok := l.level.get(); ok {
+		clone.SetLevel(level)
+	}
+
+	for _, s := range l.currentSinks() {
+		clone.sinks = append(clone.sinks, s.clone())
+	}
+
+	return clone
+}
+
+// SetLevel sets the level of l and the children created from it with With,
+// overriding the global level and any rule set with SetLevelFor.
+func (l *Logger) SetLevel(level Level) {
+	l.level.set(level)
+	lowerMinLevel(level)
+}
+
+// SetDebugLevel sets the verbosity of l and the children created from it with With,
+// overriding the global level. When v is true, debug messages are shown.
+// Otherwise, only Info and above are logged.
+//
+// Deprecated: Use l.SetLevel(DebugLevel) or l.SetLevel(InfoLevel).
+func (l *Logger) SetDebugLevel(v bool) {
+	if v {
+		l.SetLevel(DebugLevel)
+	} else {
+		l.SetLevel(InfoLevel)
+	}
+}
+
+// SetOutput replaces l's sinks with a single sink writing to w, keeping the styles
+// of l's current primary sink. Colors are used only if w is a terminal that supports
+// them, as when configuring the sink with Init. Use it on a Clone to redirect the copy without
+// affecting the Logger it was cloned from. Call it before l is shared between goroutines.
+func (l *Logger) SetOutput(w io.Writer) {
+	primary := l.currentSinks()[0].clone()
+	primary.setOutput(w)
+
+	l.sinks = []*sink{primary}
+}
+
+// currentSinks returns l's own sinks if it has any, or the sinks configured by Init.
+func (l *Logger) currentSinks() []*sink {
+	if l.sinks != nil {
+		return l.sinks
+	}
+	return currentSinks()
+}
+
+// log sends msg to every registered logger at the given level. Fields are
+// ordered metadata, baggage, pushed scopes, the Logger's own fields, then the caller's keyvals;
+// when a key repeats, the later value wins.
+func (l *Logger) log(level Level, msg string, keyvals ...any) {
+	l.logEntry(level, msg, nil, keyvals)
+}
+
+// logf logs a message formatted from formatMsg and vals at level, as log does,
+// and returns it. The format and values are passed on, so that sinks can highlight the values.
+func (l *Logger) logf(level Level, formatMsg string, vals ...any) string {
+	msg := fmt.Sprintf(formatMsg, vals...)
+	l.logEntry(level, msg, &formatArgs{format: formatMsg, args: vals}, nil)
+	return msg
+}
+
+// logEntry implements log and logf. args, if set, is what msg was formatted from.
+func (l *Logger) logEntry(level Level, msg string, args *formatArgs, keyvals []any) {
+	// The code calling into bark is past log or logf and the method calling them.
+	if !l.enabled(level, 3) {
+		return
+	}
+
+	fields := metadataFields()
+	fields = append(fields, baggageFields()...)
+	fields = append(fields, scopeFields()...)
+	fields = append(fields, l.fields...)
+	fields = append(fields, keyvals...)
+	if level >= ErrorLevel {
+		fields = appendStack(fields, keyvals)
+	}
+	fields = dedupeFields(fields)
+	if filtered(level, msg, fields) {
+		return
+	}
+	fields = resolveLazy(fields)
+	countEntry(level)
+	msg, fields = runPreHooks(level, msg, fields)
+
+	offset := -1
+	for _, s := range l.currentSinks() {
+		if s.quieted(level) {
+			continue
+		}
+		if s.reportCaller && offset < 0 {
+			offset = callerOffset()
+		}
+		s.write(level, l.prefix, msg, args, fields, offset)
+	}
+
+	runPostHooks(level, msg, fields)
+}
+
+// print writes msg to l's primary sink, bypassing levels, filters, and hooks.
+func (l *Logger) print(msg string) {
+	offset := -1
+	s := l.currentSinks()[0]
+	if s.reportCaller {
+		offset = callerOffset()
+	}
+	s.print(msg, offset)
+}
+
+// appendStack appends the stack trace of the first error value in keyvals
+// that carries one, so StackErrors logged at Error or Fatal show where they came from.
+func appendStack(fields, keyvals []any) []any {
+	for i := 1; i < len(keyvals); i += 2 {
+		if err, ok := keyvals[i].(error); ok {
+			if stack := stackField(err); stack != nil {
+				return append(fields, stack...)
+			}
+		}
+	}
+	return fields
+}
+
+// Info logs a message at Info level.
+func (l *Logger) Info(msg string, keyvals ...any) {
+	l.log(InfoLevel, msg, keyvals...)
+}
+
+// Infof logs a formatted message at Info level.
+func (l *Logger) Infof(formatMsg string, vals ...any) {
+	l.logf(InfoLevel, formatMsg, vals...)
+}
+
+// Success logs a positive completion message. It is filtered like Info.
+func (l *Logger) Success(msg string, keyvals ...any) {
+	l.log(SuccessLevel, msg, keyvals...)
+}
+
+// Successf logs a formatted positive completion message. It is filtered like Info.
+func (l *Logger) Successf(formatMsg string, vals ...any) {
+	l.logf(SuccessLevel, formatMsg, vals...)
+}
+
+// Notice logs a message at Notice level, between Info and Warn.
+func (l *Logger) Notice(msg string, keyvals ...any) {
+	l.log(NoticeLevel, msg, keyvals...)
+}
+
+// Noticef logs a formatted message at Notice level, between Info and Warn.
+func (l *Logger) Noticef(formatMsg string, vals ...any) {
+	l.logf(NoticeLevel, formatMsg, vals...)
+}
+
+// Warn logs a message at Warn level.
+func (l *Logger) Warn(msg string, keyvals ...any) {
+	l.log(WarnLevel, msg, keyvals...)
+}
+
+// Warnf logs a formatted message at Warn level.
+func (l *Logger) Warnf(formatMsg string, vals ...any) {
+	l.logf(WarnLevel, formatMsg, vals...)
+}
+
+// Error logs a message at Error level.
+func (l *Logger) Error(msg string, keyvals ...any) {
+	l.log(ErrorLevel, msg, keyvals...)
+}
+
+// Errorf logs a formatted message at Error level.
+func (l *Logger) Errorf(formatMsg string, vals ...any) {
+	l.logf(ErrorLevel, formatMsg, vals...)
+}
+
+// Fatal logs a message at Fatal level and terminates the program.
+func (l *Logger) Fatal(msg string, keyvals ...any) {
+	l.log(FatalLevel, msg, keyvals...)
+	l.exit(msg)
+}
+
+// Fatalf logs a formatted message at Fatal level and terminates the program.
+func (l *Logger) Fatalf(formatMsg string, vals ...any) {
+	msg := l.logf(FatalLevel, formatMsg, vals...)
+	l.exit(msg)
+}
+
+// Panic logs a message at Panic level, flushes l's sinks, and panics with msg,
+// so deferred functions still run and callers can recover.
+func (l *Logger) Panic(msg string, keyvals ...any) {
+	l.log(PanicLevel, msg, keyvals...)
+	flushSinks(l.currentSinks())
+	panic(msg)
+}
+
+// Panicf logs a formatted message at Panic level, flushes l's sinks,
+// and panics with the formatted message.
+func (l *Logger) Panicf(formatMsg string, vals ...any) {
+	msg := l.logf(PanicLevel, formatMsg, vals...)
+	flushSinks(l.currentSinks())
+	panic(msg)
+}
+
+// Trace logs a message at Trace level, below Debug.
+func (l *Logger) Trace(msg string, keyvals ...any) {
+	l.log(TraceLevel, msg, keyvals...)
+}
+
+// Tracef logs a formatted message at Trace level, below Debug.
+func (l *Logger) Tracef(formatMsg string, vals ...any) {
+	l.logf(TraceLevel, formatMsg, vals...)
+}
+
+// Debug logs a message at Debug level.
+func (l *Logger) Debug(msg string, keyvals ...any) {
+	l.log(DebugLevel, msg, keyvals...)
+}
+
+// Debugf logs a formatted message at Debug level.
+func (l *Logger) Debugf(formatMsg string, vals ...any) {
+	l.logf(DebugLevel, formatMsg, vals...)
+}
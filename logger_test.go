@@ -0,0 +1,103 @@
+package bark_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"go.dalton.dog/bark"
+)
+
+func TestCloneSetDebugLevelLeavesParent(t *testing.T) {
+	parent, capture := bark.NewTestLogger()
+	parent.SetDebugLevel(false)
+
+	clone := parent.Clone()
+	clone.SetDebugLevel(true)
+
+	if parent.Enabled(bark.DebugLevel) {
+		t.Error("parent has Debug enabled after SetDebugLevel(true) on its clone")
+	}
+	if !clone.Enabled(bark.DebugLevel) {
+		t.Error("clone doesn't have Debug enabled after SetDebugLevel(true)")
+	}
+
+	parent.Debug("from parent")
+	clone.Debug("from clone")
+
+	bark.AssertNotLogged(t, capture, bark.DebugLevel, "from parent")
+	bark.AssertLogged(t, capture, bark.DebugLevel, "from clone")
+}
+
+func TestSuccessFiltersLikeInfo(t *testing.T) {
+	restore, capture := bark.CaptureGlobal()
+	defer restore()
+	defer bark.Reset()
+
+	if err := bark.SetMaxLevel(bark.InfoLevel); err != nil {
+		t.Fatal(err)
+	}
+	bark.Success("kept under an Info maximum")
+	bark.Notice("dropped above the maximum")
+
+	bark.AssertLogged(t, capture, bark.SuccessLevel, "kept under an Info maximum")
+	bark.AssertNotLogged(t, capture, bark.NoticeLevel, "dropped above the maximum")
+
+	bark.SetLevel(bark.SuccessLevel)
+	if bark.Enabled(bark.SuccessLevel) {
+		t.Error("Success is enabled at a level above Info")
+	}
+}
+
+// flushRecorder is a writer that records whether it was flushed.
+type flushRecorder struct {
+	bytes.Buffer
+	flushed bool
+}
+
+func (f *flushRecorder) Flush() error {
+	f.flushed = true
+	return nil
+}
+
+func TestPanicAndFatalFlushOwnSinks(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		log  func(*bark.Logger)
+	}{
+		{"Panic", func(l *bark.Logger) { l.Panic("boom") }},
+		{"Fatal", func(l *bark.Logger) { l.Fatal("boom") }},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &flushRecorder{}
+			logger, err := bark.New(bark.WithOutput(out))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			restore := bark.MockFatal()
+			defer restore()
+			logRecovered(func() { tt.log(logger) })
+
+			if !out.flushed {
+				t.Errorf("%s didn't flush the Logger's own sink", tt.name)
+			}
+		})
+	}
+}
+
+func TestCtxFunctionsUseContextLogger(t *testing.T) {
+	logger, capture := bark.NewTestLogger()
+	ctx := bark.WithContext(context.Background(), logger)
+
+	bark.SuccessCtx(ctx, "deployed")
+	bark.NoticeCtx(ctx, "deprecated flag")
+
+	restore := bark.MockFatal()
+	defer restore()
+	logRecovered(func() { bark.FatalCtx(ctx, "out of disk") })
+
+	bark.AssertLogged(t, capture, bark.SuccessLevel, "deployed")
+	bark.AssertLogged(t, capture, bark.NoticeLevel, "deprecated flag")
+	bark.AssertLogged(t, capture, bark.FatalLevel, "out of disk")
+}
@@ -0,0 +1,53 @@
+package bark
+
+import "sync"
+
+var (
+	metadataMu  sync.RWMutex
+	appName     string
+	appVersion  string
+	environment string
+)
+
+// SetAppName attaches an "app" field with the given name to every log entry.
+// Passing an empty string removes the field.
+func SetAppName(name string) {
+	metadataMu.Lock()
+	defer metadataMu.Unlock()
+	appName = name
+}
+
+// SetVersion attaches a "version" field with the given version to every log entry.
+// Passing an empty string removes the field.
+func SetVersion(version string) {
+	metadataMu.Lock()
+	defer metadataMu.Unlock()
+	appVersion = version
+}
+
+// SetEnvironment attaches an "env" field with the given environment to every log entry.
+// Passing an empty string removes the field.
+func SetEnvironment(env string) {
+	metadataMu.Lock()
+	defer metadataMu.Unlock()
+	environment = env
+}
+
+// metadataFields returns the keyvals for whichever metadata fields are set.
+func metadataFields() []any {
+	metadataMu.RLock()
+	defer metadataMu.RUnlock()
+
+	var fields []any
+	if appName != "" {
+		fields = append(fields, "app", appName)
+	}
+	if appVersion != "" {
+		fields = append(fields, "version", appVersion)
+	}
+	if environment != "" {
+		fields = append(fields, "env", environment)
+	}
+
+	return fields
+}
@@ -0,0 +1,26 @@
+package bark
+
+import "sync"
+
+var onceKeys sync.Map
+
+// LogOnce logs msg at the given level the first time it is called with key,
+// and does nothing on every later call with the same key.
+// Useful for startup or configuration messages reached from inside loops.
+func LogOnce(key string, level Level, msg string, keyvals ...any) {
+	if _, seen := onceKeys.LoadOrStore(key, struct{}{}); seen {
+		return
+	}
+
+	std.log(level, msg, keyvals...)
+}
+
+// ResetOnce forgets key so the next LogOnce call with it logs again.
+func ResetOnce(key string) {
+	onceKeys.Delete(key)
+}
+
+// ResetAllOnce forgets every key seen by LogOnce.
+func ResetAllOnce() {
+	onceKeys.Clear()
+}
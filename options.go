@@ -0,0 +1,1176 @@
+package bark
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// builtinOptions are bark's own defaults.
+var builtinOptions BarkOptions = BarkOptions{
+	InfoHex:    "#1982c4",
+	WarnHex:    "#ffca3a",
+	ErrorHex:   "#ff595e",
+	DebugHex:   "#ca7df9",
+	TraceHex:   "#8d99ae",
+	SuccessHex: "#8ac926",
+	NoticeHex:  "#4cc9f0",
+	PanicHex:   "#ff595e",
+
+	InfoHexLight:    "#0f5e91",
+	WarnHexLight:    "#b7791f",
+	ErrorHexLight:   "#c62828",
+	DebugHexLight:   "#7b2cbf",
+	TraceHexLight:   "#5c677d",
+	SuccessHexLight: "#3a7d0a",
+	NoticeHexLight:  "#0077b6",
+	PanicHexLight:   "#c62828",
+
+	TimeFormat: "01/02 03:04:05PM",
+	LevelEnv:   "BARK_LEVEL",
+}
+
+var (
+	defaultsMu sync.RWMutex
+	// defaultOptions are applied on top of builtinOptions by every Init.
+	defaultOptions BarkOptions
+)
+
+// BarkOptions specifies configuration for colors and time formatting.
+// It can be passed to Init directly; empty fields keep their defaults.
+//
+// Despite their names, the color fields accept any of "#RGB" or "#RRGGBB" hex
+// strings, ANSI palette indices from "0" to "255", and CSS color names such as
+// "tomato" or "steelblue". Names are stored as their hex values.
+type BarkOptions struct {
+	InfoHex    string
+	WarnHex    string
+	ErrorHex   string
+	DebugHex   string
+	TraceHex   string
+	SuccessHex string
+	NoticeHex  string
+	PanicHex   string
+
+	// The *Light colors replace the ones above on terminals with a light background.
+	// Setting one of the colors above without its light variant uses it for both.
+	InfoHexLight    string
+	WarnHexLight    string
+	ErrorHexLight   string
+	DebugHexLight   string
+	TraceHexLight   string
+	SuccessHexLight string
+	NoticeHexLight  string
+	PanicHexLight   string
+
+	TimeFormat string
+
+	// OutputFormat selects how entries are rendered. The default is FormatPretty.
+	OutputFormat Format
+
+	// ReportCaller adds the file and line of each log call to its entry.
+	ReportCaller bool
+	// CallerSkip skips additional frames when reporting the caller,
+	// for code that wraps bark without using Helper.
+	CallerSkip int
+
+	// LevelEnv names the environment variable Init reads the global level from.
+	// The default is BARK_LEVEL.
+	LevelEnv string
+
+	// Quiet turns on quiet mode, as SetQuiet(true) does.
+	Quiet bool
+
+	// Color overrides whether pretty sinks use colors, e.g. for a --color flag.
+	// The default, ColorAuto, follows NO_COLOR, CLICOLOR, and CLICOLOR_FORCE,
+	// then whether the output supports colors.
+	Color ColorMode
+	// ForceColorProfile overrides the detected color support of colored sinks,
+	// for terminals and CI environments that misreport it. The default,
+	// ProfileAuto, keeps detection. It doesn't turn colors on where Color or the
+	// environment turns them off.
+	ForceColorProfile ColorProfile
+
+	// BadgeStyle selects how level badges are drawn. The default is BadgeText.
+	BadgeStyle BadgeStyle
+	// BadgeWidth is the width, in cells, that level names are padded to in
+	// their badges, and BadgeAlign where they sit within it. The default width is
+	// 6, that of the longest name, NOTICE; narrower widths are rejected.
+	BadgeWidth int
+	BadgeAlign BadgeAlign
+	// Icons selects whether badges start with a level icon. The default is IconsOff.
+	Icons IconMode
+	// Compact draws each level as a single colored symbol, such as ● for Info
+	// and ▲ for Warn, in place of its badge, BadgeStyle, and Icons. Pair it with
+	// TimeFormat set to ShortTimeFormat for the narrowest lines.
+	Compact bool
+	// FatalBox draws the messages of Fatal entries written to terminals in a box
+	// bordered in the Error color, word-wrapped to the terminal's width, below
+	// the entry's badge and fields. Other sinks get the usual line.
+	FatalBox bool
+	// HighlightArgs makes the values in messages logged with Infof and the other
+	// *f functions stand out in pretty output, bold unless set with WithHighlightStyle,
+	// as in "loaded **42** records from **users.csv**". Messages whose format can't
+	// be split into its values confidently are shown as usual.
+	HighlightArgs bool
+	// TruncateToWidth clips messages in pretty output written to a terminal, so
+	// entries fit its width rather than wrapping, ending clipped ones with "…".
+	// The badge, timestamp, and fields are kept whole. Structured output is never clipped.
+	TruncateToWidth bool
+
+	// KeyHex and ValueHex color the keys and values of fields in pretty output.
+	// By default keys are dimmed and values are plain. Fields named "err" or
+	// "error" always take the Error color for their values.
+	KeyHex   string
+	ValueHex string
+	// TimestampHex colors timestamps in pretty output. By default they are dimmed.
+	TimestampHex string
+	// HideKeySeparator writes fields in pretty output as "key value" rather than "key=value".
+	HideKeySeparator bool
+
+	// PrefixHex colors the prefixes of Loggers in pretty output, in place of the
+	// default bold, dimmed style. Each segment of a nested prefix is styled alike.
+	PrefixHex string
+	// PrefixSeparator follows each prefix segment in pretty output, ":" if empty.
+	// Use WithPrefixSeparator to drop it altogether.
+	PrefixSeparator string
+	// SectionHex colors the rules drawn by Section on terminals. By default
+	// they take the Info color.
+	SectionHex string
+
+	// The *Style fields, when set, replace the badge of their level in pretty output
+	// with the given style, used as is rather than built from the level's colors.
+	// The badge text is the style's string, set with SetString; a style without
+	// one gets the usual label, such as " INFO ". Bark's own badges are BadgeWidth
+	// cells plus one cell of padding on each side, eight in all by default, so
+	// keep custom ones as wide, e.g. with Padding(0, 1), for entries to line up.
+	InfoStyle    *lipgloss.Style
+	WarnStyle    *lipgloss.Style
+	ErrorStyle   *lipgloss.Style
+	DebugStyle   *lipgloss.Style
+	FatalStyle   *lipgloss.Style
+	TraceStyle   *lipgloss.Style
+	SuccessStyle *lipgloss.Style
+	NoticeStyle  *lipgloss.Style
+	PanicStyle   *lipgloss.Style
+}
+
+// Option configures Init. Options are applied in order, so later ones
+// override earlier ones. BarkOptions is itself an Option.
+type Option interface {
+	apply(cfg *config) error
+}
+
+// optionFunc adapts a function to the Option interface.
+type optionFunc func(cfg *config) error
+
+func (f optionFunc) apply(cfg *config) error {
+	return f(cfg)
+}
+
+// config is the fully resolved configuration built from a set of Options.
+type config struct {
+	infoHex    string
+	warnHex    string
+	errorHex   string
+	debugHex   string
+	traceHex   string
+	successHex string
+	noticeHex  string
+	panicHex   string
+
+	// The light variants of the colors above, or "" to use the same color.
+	infoLight    string
+	warnLight    string
+	errorLight   string
+	debugLight   string
+	traceLight   string
+	successLight string
+	noticeLight  string
+	panicLight   string
+
+	timeFormat string
+	output     io.Writer
+	format     Format
+
+	reportCaller bool
+	callerSkip   int
+
+	levelEnv string
+	quiet    bool
+	color    ColorMode
+
+	colorProfile ColorProfile
+
+	badgeStyle BadgeStyle
+	badgeWidth int
+	badgeAlign BadgeAlign
+	icons      IconMode
+
+	// compactSymbols holds the symbols set with WithCompactSymbol. It is shared
+	// between copies of a config, so it is replaced rather than modified.
+	compact        bool
+	compactSymbols map[Level]string
+	fatalBox       bool
+
+	// highlightArgs turns on highlightStyle for the values in formatted messages.
+	highlightArgs  bool
+	highlightStyle lipgloss.Style
+
+	truncate bool
+	// messageStyles and levelStyles are shared between copies of a config,
+	// so they are replaced rather than modified.
+	messageStyles map[Level]lipgloss.Style
+	levelStyles   map[Level]lipgloss.Style
+
+	// keyStyle and valueStyle style fields in pretty output. keyHex and valueHex
+	// record the colors they were built from, if any, for introspection.
+	keyStyle      lipgloss.Style
+	valueStyle    lipgloss.Style
+	keyHex        string
+	valueHex      string
+	hideSeparator bool
+
+	// timestampStyle styles timestamps in pretty output; timestampHex is as keyHex.
+	timestampStyle lipgloss.Style
+	timestampHex   string
+
+	// prefixStyle styles each prefix segment in pretty output, followed by
+	// prefixSeparator; prefixHex is as keyHex.
+	prefixStyle     lipgloss.Style
+	prefixHex       string
+	prefixSeparator string
+
+	// sectionHex colors Section rules, or is empty for the Info color.
+	sectionHex string
+}
+
+// newConfig starts from the defaults and applies opts in order,
+// returning an error describing every option that failed validation.
+func newConfig(opts ...Option) (config, error) {
+	defaultsMu.RLock()
+	defaults := defaultOptions
+	defaultsMu.RUnlock()
+
+	// Both sets of defaults are known to be valid.
+	cfg := config{
+		output:        os.Stderr,
+		messageStyles: defaultMessageStyles(),
+		keyStyle:      lipgloss.NewStyle().Faint(true),
+		valueStyle:    lipgloss.NewStyle(),
+
+		timestampStyle: lipgloss.NewStyle().Faint(true),
+
+		prefixStyle:     lipgloss.NewStyle().Bold(true).Faint(true),
+		prefixSeparator: ":",
+
+		highlightStyle: lipgloss.NewStyle().Bold(true),
+	}
+	builtinOptions.apply(&cfg)
+	defaults.apply(&cfg)
+
+	return cfg.with(opts...)
+}
+
+// with returns a copy of cfg with opts applied in order,
+// returning an error describing every option that failed validation.
+func (cfg config) with(opts ...Option) (config, error) {
+	var problems []string
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if err := opt.apply(&cfg); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if len(problems) > 0 {
+		return cfg, fmt.Errorf("invalid options: %s", strings.Join(problems, "; "))
+	}
+
+	return cfg, nil
+}
+
+// options returns cfg expressed as BarkOptions.
+func (cfg config) options() BarkOptions {
+	return BarkOptions{
+		InfoHex:    cfg.infoHex,
+		WarnHex:    cfg.warnHex,
+		ErrorHex:   cfg.errorHex,
+		DebugHex:   cfg.debugHex,
+		TraceHex:   cfg.traceHex,
+		SuccessHex: cfg.successHex,
+		NoticeHex:  cfg.noticeHex,
+		PanicHex:   cfg.panicHex,
+
+		InfoHexLight:    cfg.infoLight,
+		WarnHexLight:    cfg.warnLight,
+		ErrorHexLight:   cfg.errorLight,
+		DebugHexLight:   cfg.debugLight,
+		TraceHexLight:   cfg.traceLight,
+		SuccessHexLight: cfg.successLight,
+		NoticeHexLight:  cfg.noticeLight,
+		PanicHexLight:   cfg.panicLight,
+
+		TimeFormat:   cfg.timeFormat,
+		OutputFormat: cfg.format,
+		ReportCaller: cfg.reportCaller,
+		CallerSkip:   cfg.callerSkip,
+		LevelEnv:     cfg.levelEnv,
+		Quiet:        cfg.quiet,
+		Color:        cfg.color,
+		BadgeStyle:   cfg.badgeStyle,
+		BadgeWidth:   cmp.Or(cfg.badgeWidth, minBadgeWidth),
+		BadgeAlign:   cfg.badgeAlign,
+		Icons:        cfg.icons,
+		Compact:      cfg.compact,
+		FatalBox:     cfg.fatalBox,
+
+		HighlightArgs:   cfg.highlightArgs,
+		TruncateToWidth: cfg.truncate,
+
+		ForceColorProfile: cfg.colorProfile,
+
+		KeyHex:           cfg.keyHex,
+		ValueHex:         cfg.valueHex,
+		TimestampHex:     cfg.timestampHex,
+		HideKeySeparator: cfg.hideSeparator,
+		PrefixHex:        cfg.prefixHex,
+		PrefixSeparator:  cfg.prefixSeparator,
+		SectionHex:       cfg.sectionHex,
+
+		InfoStyle:    cfg.levelStyle(InfoLevel),
+		WarnStyle:    cfg.levelStyle(WarnLevel),
+		ErrorStyle:   cfg.levelStyle(ErrorLevel),
+		DebugStyle:   cfg.levelStyle(DebugLevel),
+		FatalStyle:   cfg.levelStyle(FatalLevel),
+		TraceStyle:   cfg.levelStyle(TraceLevel),
+		SuccessStyle: cfg.levelStyle(SuccessLevel),
+		NoticeStyle:  cfg.levelStyle(NoticeLevel),
+		PanicStyle:   cfg.levelStyle(PanicLevel),
+	}
+}
+
+// levelStyle returns the custom badge style of level in cfg, or nil if it has none.
+func (cfg config) levelStyle(level Level) *lipgloss.Style {
+	style, ok := cfg.levelStyles[level]
+	if !ok {
+		return nil
+	}
+	return &style
+}
+
+// SetDefaultOptions replaces the defaults used for anything not set explicitly
+// in later Init calls, e.g. to establish house colors once. Empty fields fall back
+// to bark's built-in defaults, so SetDefaultOptions(BarkOptions{}) restores them.
+//
+// Sinks that already exist are not restyled: the new defaults take effect at the
+// next Init. Named and child Loggers always use the current sinks, so they pick up
+// the change along with everything else at that point.
+// An invalid opts returns an error and leaves the defaults unchanged.
+func SetDefaultOptions(opts BarkOptions) error {
+	var cfg config
+	if err := opts.apply(&cfg); err != nil {
+		return fmt.Errorf("invalid default options: %v", err)
+	}
+
+	defaultsMu.Lock()
+	defer defaultsMu.Unlock()
+	defaultOptions = opts
+
+	return nil
+}
+
+// apply lets BarkOptions be passed to Init. Only non-empty fields are applied,
+// and every invalid field is reported rather than just the first.
+func (opts BarkOptions) apply(cfg *config) error {
+	var problems []string
+
+	colors := []struct {
+		name       string
+		value      string
+		lightValue string
+		dest       *string
+		lightDest  *string
+	}{
+		{"InfoHex", opts.InfoHex, opts.InfoHexLight, &cfg.infoHex, &cfg.infoLight},
+		{"WarnHex", opts.WarnHex, opts.WarnHexLight, &cfg.warnHex, &cfg.warnLight},
+		{"ErrorHex", opts.ErrorHex, opts.ErrorHexLight, &cfg.errorHex, &cfg.errorLight},
+		{"DebugHex", opts.DebugHex, opts.DebugHexLight, &cfg.debugHex, &cfg.debugLight},
+		{"TraceHex", opts.TraceHex, opts.TraceHexLight, &cfg.traceHex, &cfg.traceLight},
+		{"SuccessHex", opts.SuccessHex, opts.SuccessHexLight, &cfg.successHex, &cfg.successLight},
+		{"NoticeHex", opts.NoticeHex, opts.NoticeHexLight, &cfg.noticeHex, &cfg.noticeLight},
+		{"PanicHex", opts.PanicHex, opts.PanicHexLight, &cfg.panicHex, &cfg.panicLight},
+	}
+	for _, color := range colors {
+		if color.value != "" {
+			if err := setBadgeColor(color.name, color.value, color.dest, color.lightDest); err != nil {
+				problems = append(problems, err.Error())
+			}
+		}
+		if color.lightValue != "" {
+			if err := setColor(color.name+"Light", color.lightValue, color.lightDest); err != nil {
+				problems = append(problems, err.Error())
+			}
+		}
+	}
+
+	if opts.TimeFormat != "" {
+		if err := validateTimeFormat(opts.TimeFormat); err != nil {
+			problems = append(problems, fmt.Sprintf("TimeFormat: %v", err))
+		} else {
+			cfg.timeFormat = opts.TimeFormat
+		}
+	}
+
+	if opts.OutputFormat != FormatPretty {
+		if err := setFormat("OutputFormat", opts.OutputFormat, &cfg.format); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if opts.ReportCaller {
+		cfg.reportCaller = true
+	}
+
+	if opts.CallerSkip != 0 {
+		if err := setCallerSkip("CallerSkip", opts.CallerSkip, &cfg.callerSkip); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if opts.LevelEnv != "" {
+		cfg.levelEnv = opts.LevelEnv
+	}
+
+	if opts.Quiet {
+		cfg.quiet = true
+	}
+
+	if opts.Color != ColorAuto {
+		if err := setColorMode("Color", opts.Color, &cfg.color); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if opts.ForceColorProfile != ProfileAuto {
+		if err := setColorProfile("ForceColorProfile", opts.ForceColorProfile, &cfg.colorProfile); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if opts.BadgeStyle != BadgeText {
+		if err := setBadgeStyle("BadgeStyle", opts.BadgeStyle, &cfg.badgeStyle); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if opts.BadgeWidth != 0 {
+		if err := setBadgeWidth("BadgeWidth", opts.BadgeWidth, &cfg.badgeWidth); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if opts.BadgeAlign != AlignCenter {
+		if err := setBadgeAlign("BadgeAlign", opts.BadgeAlign, &cfg.badgeAlign); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if opts.Icons != IconsOff {
+		if err := setIconMode("Icons", opts.Icons, &cfg.icons); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if opts.Compact {
+		cfg.compact = true
+	}
+
+	if opts.FatalBox {
+		cfg.fatalBox = true
+	}
+
+	if opts.HighlightArgs {
+		cfg.highlightArgs = true
+	}
+
+	if opts.TruncateToWidth {
+		cfg.truncate = true
+	}
+
+	if opts.KeyHex != "" {
+		if err := setFieldColor("KeyHex", opts.KeyHex, &cfg.keyHex, &cfg.keyStyle); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if opts.ValueHex != "" {
+		if err := setFieldColor("ValueHex", opts.ValueHex, &cfg.valueHex, &cfg.valueStyle); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if opts.TimestampHex != "" {
+		if err := setFieldColor("TimestampHex", opts.TimestampHex, &cfg.timestampHex, &cfg.timestampStyle); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if opts.HideKeySeparator {
+		cfg.hideSeparator = true
+	}
+
+	if opts.PrefixHex != "" {
+		if err := setFieldColor("PrefixHex", opts.PrefixHex, &cfg.prefixHex, &cfg.prefixStyle); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if opts.PrefixSeparator != "" {
+		cfg.prefixSeparator = opts.PrefixSeparator
+	}
+
+	if opts.SectionHex != "" {
+		if err := setColor("SectionHex", opts.SectionHex, &cfg.sectionHex); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	levelStyles := []struct {
+		level Level
+		style *lipgloss.Style
+	}{
+		{InfoLevel, opts.InfoStyle},
+		{WarnLevel, opts.WarnStyle},
+		{ErrorLevel, opts.ErrorStyle},
+		{DebugLevel, opts.DebugStyle},
+		{FatalLevel, opts.FatalStyle},
+		{TraceLevel, opts.TraceStyle},
+		{SuccessLevel, opts.SuccessStyle},
+		{NoticeLevel, opts.NoticeStyle},
+		{PanicLevel, opts.PanicStyle},
+	}
+	for _, ls := range levelStyles {
+		if ls.style != nil {
+			cfg.setLevelStyle(ls.level, *ls.style)
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// WithInfoColor sets the color of the Info level badge as a hex string, ANSI index, or color name.
+func WithInfoColor(hex string) Option {
+	return optionFunc(func(cfg *config) error {
+		return setBadgeColor("WithInfoColor", hex, &cfg.infoHex, &cfg.infoLight)
+	})
+}
+
+// WithWarnColor sets the color of the Warn level badge as a hex string, ANSI index, or color name.
+func WithWarnColor(hex string) Option {
+	return optionFunc(func(cfg *config) error {
+		return setBadgeColor("WithWarnColor", hex, &cfg.warnHex, &cfg.warnLight)
+	})
+}
+
+// WithErrorColor sets the color of the Error and Fatal level badges as a hex string, ANSI index, or color name.
+func WithErrorColor(hex string) Option {
+	return optionFunc(func(cfg *config) error {
+		return setBadgeColor("WithErrorColor", hex, &cfg.errorHex, &cfg.errorLight)
+	})
+}
+
+// WithDebugColor sets the color of the Debug level badge as a hex string, ANSI index, or color name.
+func WithDebugColor(hex string) Option {
+	return optionFunc(func(cfg *config) error {
+		return setBadgeColor("WithDebugColor", hex, &cfg.debugHex, &cfg.debugLight)
+	})
+}
+
+// WithTraceColor sets the color of the Trace level badge as a hex string, ANSI index, or color name.
+func WithTraceColor(hex string) Option {
+	return optionFunc(func(cfg *config) error {
+		return setBadgeColor("WithTraceColor", hex, &cfg.traceHex, &cfg.traceLight)
+	})
+}
+
+// WithSuccessColor sets the color of the Success level badge as a hex string, ANSI index, or color name.
+func WithSuccessColor(hex string) Option {
+	return optionFunc(func(cfg *config) error {
+		return setBadgeColor("WithSuccessColor", hex, &cfg.successHex, &cfg.successLight)
+	})
+}
+
+// WithNoticeColor sets the color of the Notice level badge as a hex string, ANSI index, or color name.
+func WithNoticeColor(hex string) Option {
+	return optionFunc(func(cfg *config) error {
+		return setBadgeColor("WithNoticeColor", hex, &cfg.noticeHex, &cfg.noticeLight)
+	})
+}
+
+// WithPanicColor sets the color of the Panic level badge as a hex string, ANSI index, or color name.
+// It defaults to the same color as Error and Fatal.
+func WithPanicColor(hex string) Option {
+	return optionFunc(func(cfg *config) error {
+		return setBadgeColor("WithPanicColor", hex, &cfg.panicHex, &cfg.panicLight)
+	})
+}
+
+// WithLightColor sets the color of level's badge on terminals with a light background,
+// in any form BarkOptions accepts, leaving the color for dark backgrounds as it is.
+// Apply it after the matching With*Color option, which uses one color for both.
+// The Error level's light color also applies to Fatal.
+func WithLightColor(level Level, hex string) Option {
+	return optionFunc(func(cfg *config) error {
+		dest := cfg.lightColor(level)
+		if dest == nil {
+			return fmt.Errorf("WithLightColor: level %s has no configurable color", levelName(level))
+		}
+		return setColor("WithLightColor", hex, dest)
+	})
+}
+
+// lightColor returns the light variant of level's badge color in cfg, or nil if
+// the level's color isn't configured through options.
+func (cfg *config) lightColor(level Level) *string {
+	switch level {
+	case InfoLevel:
+		return &cfg.infoLight
+	case WarnLevel:
+		return &cfg.warnLight
+	case ErrorLevel, FatalLevel:
+		return &cfg.errorLight
+	case DebugLevel:
+		return &cfg.debugLight
+	case TraceLevel:
+		return &cfg.traceLight
+	case SuccessLevel:
+		return &cfg.successLight
+	case NoticeLevel:
+		return &cfg.noticeLight
+	case PanicLevel:
+		return &cfg.panicLight
+	}
+	return nil
+}
+
+// ShortTimeFormat is a time layout showing only the time of day, as HH:MM:SS,
+// for narrow terminals.
+const ShortTimeFormat = "15:04:05"
+
+// WithTimeFormat sets the layout used for timestamps, as understood by time.Format.
+// An empty layout disables timestamps entirely.
+func WithTimeFormat(layout string) Option {
+	return optionFunc(func(cfg *config) error {
+		if layout != "" {
+			if err := validateTimeFormat(layout); err != nil {
+				return fmt.Errorf("WithTimeFormat: %v", err)
+			}
+		}
+		cfg.timeFormat = layout
+		return nil
+	})
+}
+
+// WithOutput sets the writer log entries are written to. The default is os.Stderr.
+func WithOutput(w io.Writer) Option {
+	return optionFunc(func(cfg *config) error {
+		if w == nil {
+			return fmt.Errorf("WithOutput: writer is nil")
+		}
+		cfg.output = w
+		return nil
+	})
+}
+
+// WithFormat sets how entries are rendered. The default is FormatPretty.
+func WithFormat(format Format) Option {
+	return optionFunc(func(cfg *config) error {
+		return setFormat("WithFormat", format, &cfg.format)
+	})
+}
+
+// WithReportCaller adds the file and line of each log call to its entry,
+// shortened to the last two path segments in pretty mode and reported
+// as a "caller" field in structured formats.
+func WithReportCaller(report bool) Option {
+	return optionFunc(func(cfg *config) error {
+		cfg.reportCaller = report
+		return nil
+	})
+}
+
+// WithCallerSkip skips n additional frames when reporting the caller,
+// for code that wraps bark without marking itself with Helper.
+func WithCallerSkip(n int) Option {
+	return optionFunc(func(cfg *config) error {
+		return setCallerSkip("WithCallerSkip", n, &cfg.callerSkip)
+	})
+}
+
+// WithLevelEnv sets the environment variable Init reads the initial global level
+// from, as parsed by ParseLevel. The default is BARK_LEVEL; an empty name
+// disables reading the level from the environment.
+func WithLevelEnv(name string) Option {
+	return optionFunc(func(cfg *config) error {
+		cfg.levelEnv = name
+		return nil
+	})
+}
+
+// WithQuiet turns quiet mode on or off. See SetQuiet.
+func WithQuiet(v bool) Option {
+	return optionFunc(func(cfg *config) error {
+		cfg.quiet = v
+		return nil
+	})
+}
+
+// WithColor overrides whether pretty sinks use colors, e.g. for a --color flag.
+// ColorAlways and ColorNever win over the NO_COLOR, CLICOLOR, and CLICOLOR_FORCE
+// environment variables; the default, ColorAuto, follows them.
+func WithColor(mode ColorMode) Option {
+	return optionFunc(func(cfg *config) error {
+		return setColorMode("WithColor", mode, &cfg.color)
+	})
+}
+
+// WithColorProfile forces the color support colored sinks render for,
+// e.g. ProfileANSI256 in CI that claims truecolor but can't show it.
+// See BarkOptions.ForceColorProfile.
+func WithColorProfile(profile ColorProfile) Option {
+	return optionFunc(func(cfg *config) error {
+		return setColorProfile("WithColorProfile", profile, &cfg.colorProfile)
+	})
+}
+
+// setColorMode validates mode and stores it in dest, naming the offending option on failure.
+func setColorMode(name string, mode ColorMode, dest *ColorMode) error {
+	if mode.String() == "unknown" {
+		return fmt.Errorf("%s: unknown color mode %d", name, mode)
+	}
+	*dest = mode
+	return nil
+}
+
+// setColorProfile validates profile and stores it in dest, naming the offending option on failure.
+func setColorProfile(name string, profile ColorProfile, dest *ColorProfile) error {
+	if profile.String() == "unknown" {
+		return fmt.Errorf("%s: unknown color profile %d", name, profile)
+	}
+	*dest = profile
+	return nil
+}
+
+// WithMessageStyle sets the style of the message text of entries at level in
+// pretty output, e.g. to tint Error messages red:
+//
+//	bark.Init(bark.WithMessageStyle(bark.ErrorLevel, lipgloss.NewStyle().Foreground(lipgloss.Color("#ff595e"))))
+//
+// By default Trace and Debug messages are faint, Error, Panic, and Fatal messages
+// are bold, and the rest are plain; pass lipgloss.NewStyle() to make a level plain.
+func WithMessageStyle(level Level, style lipgloss.Style) Option {
+	return optionFunc(func(cfg *config) error {
+		styles := maps.Clone(cfg.messageStyles)
+		if styles == nil {
+			styles = map[Level]lipgloss.Style{}
+		}
+		styles[level] = style
+		cfg.messageStyles = styles
+		return nil
+	})
+}
+
+// WithLevelStyle replaces the badge of one of bark's own levels in pretty output
+// with style, e.g. to underline the Fatal label:
+//
+//	bark.Init(bark.WithLevelStyle(bark.FatalLevel, lipgloss.NewStyle().Underline(true).Padding(0, 1)))
+//
+// See the *Style fields of BarkOptions for how the style is used. Levels added
+// with RegisterLevel are styled there instead.
+func WithLevelStyle(level Level, style lipgloss.Style) Option {
+	return optionFunc(func(cfg *config) error {
+		if _, ok := badgeLabels[level]; !ok {
+			return fmt.Errorf("WithLevelStyle: level %s has no badge to style", levelName(level))
+		}
+		cfg.setLevelStyle(level, style)
+		return nil
+	})
+}
+
+// setLevelStyle sets the custom badge style of level in cfg.
+func (cfg *config) setLevelStyle(level Level, style lipgloss.Style) {
+	styles := maps.Clone(cfg.levelStyles)
+	if styles == nil {
+		styles = map[Level]lipgloss.Style{}
+	}
+	styles[level] = style
+	cfg.levelStyles = styles
+}
+
+// WithKeyColor sets the color of field keys in pretty output as a hex string,
+// ANSI index, or color name. Colored keys are not dimmed.
+func WithKeyColor(hex string) Option {
+	return optionFunc(func(cfg *config) error {
+		return setFieldColor("WithKeyColor", hex, &cfg.keyHex, &cfg.keyStyle)
+	})
+}
+
+// WithValueColor sets the color of field values in pretty output as a hex string, ANSI index, or color name.
+func WithValueColor(hex string) Option {
+	return optionFunc(func(cfg *config) error {
+		return setFieldColor("WithValueColor", hex, &cfg.valueHex, &cfg.valueStyle)
+	})
+}
+
+// WithKeyStyle sets the style of field keys in pretty output, replacing the
+// default dimmed style and any WithKeyColor.
+func WithKeyStyle(style lipgloss.Style) Option {
+	return optionFunc(func(cfg *config) error {
+		cfg.keyStyle, cfg.keyHex = style, ""
+		return nil
+	})
+}
+
+// WithValueStyle sets the style of field values in pretty output, replacing any
+// WithValueColor. Values of "err" and "error" fields keep the Error color.
+func WithValueStyle(style lipgloss.Style) Option {
+	return optionFunc(func(cfg *config) error {
+		cfg.valueStyle, cfg.valueHex = style, ""
+		return nil
+	})
+}
+
+// WithTimestampColor sets the color of timestamps in pretty output as a hex string,
+// ANSI index, or color name. Colored timestamps are not dimmed.
+func WithTimestampColor(hex string) Option {
+	return optionFunc(func(cfg *config) error {
+		return setFieldColor("WithTimestampColor", hex, &cfg.timestampHex, &cfg.timestampStyle)
+	})
+}
+
+// WithTimestampStyle sets the style of timestamps in pretty output, replacing the
+// default dimmed style and any WithTimestampColor, e.g. lipgloss.NewStyle().Bold(true).
+func WithTimestampStyle(style lipgloss.Style) Option {
+	return optionFunc(func(cfg *config) error {
+		cfg.timestampStyle, cfg.timestampHex = style, ""
+		return nil
+	})
+}
+
+// WithPrefixColor sets the color of Logger prefixes in pretty output as a hex string,
+// ANSI index, or color name. Colored prefixes are neither bold nor dimmed.
+func WithPrefixColor(hex string) Option {
+	return optionFunc(func(cfg *config) error {
+		return setFieldColor("WithPrefixColor", hex, &cfg.prefixHex, &cfg.prefixStyle)
+	})
+}
+
+// WithPrefixStyle sets the style of Logger prefixes in pretty output, replacing the
+// default bold, dimmed style and any WithPrefixColor. Each segment of a nested
+// prefix, such as "http" and "auth" in "http: auth:", is rendered with it separately.
+func WithPrefixStyle(style lipgloss.Style) Option {
+	return optionFunc(func(cfg *config) error {
+		cfg.prefixStyle, cfg.prefixHex = style, ""
+		return nil
+	})
+}
+
+// WithPrefixSeparator sets the text following each prefix segment in pretty output,
+// ":" by default. An empty separator leaves the segments bare. Structured formats
+// are unaffected: they write the unstyled prefix, its segments joined with ".",
+// as a "prefix" field.
+func WithPrefixSeparator(sep string) Option {
+	return optionFunc(func(cfg *config) error {
+		cfg.prefixSeparator = sep
+		return nil
+	})
+}
+
+// WithSectionColor sets the color of the rules drawn by Section on terminals as
+// a hex string, ANSI index, or color name, in place of the Info color.
+func WithSectionColor(hex string) Option {
+	return optionFunc(func(cfg *config) error {
+		return setColor("WithSectionColor", hex, &cfg.sectionHex)
+	})
+}
+
+// WithKeySeparator sets whether pretty output joins field keys and values with "="
+// (the default) or just a space.
+func WithKeySeparator(show bool) Option {
+	return optionFunc(func(cfg *config) error {
+		cfg.hideSeparator = !show
+		return nil
+	})
+}
+
+// WithBadgeStyle selects how level badges are drawn: BadgeText, the default,
+// colors the level name, while BadgeBlock draws it on a block of the level's color.
+func WithBadgeStyle(style BadgeStyle) Option {
+	return optionFunc(func(cfg *config) error {
+		return setBadgeStyle("WithBadgeStyle", style, &cfg.badgeStyle)
+	})
+}
+
+// setBadgeStyle validates style and stores it in dest, naming the offending option on failure.
+func setBadgeStyle(name string, style BadgeStyle, dest *BadgeStyle) error {
+	if style.String() == "unknown" {
+		return fmt.Errorf("%s: unknown badge style %d", name, style)
+	}
+	*dest = style
+	return nil
+}
+
+// WithBadgeWidth sets the width, in cells, that level names are padded to in
+// their badges. It must be at least 6, the width of the longest name.
+func WithBadgeWidth(width int) Option {
+	return optionFunc(func(cfg *config) error {
+		return setBadgeWidth("WithBadgeWidth", width, &cfg.badgeWidth)
+	})
+}
+
+// setBadgeWidth validates width and stores it in dest, naming the offending option on failure.
+func setBadgeWidth(name string, width int, dest *int) error {
+	if width < minBadgeWidth {
+		return fmt.Errorf("%s: width %d is narrower than the longest level name, %d", name, width, minBadgeWidth)
+	}
+	*dest = width
+	return nil
+}
+
+// WithBadgeAlign sets where level names sit within their badges:
+// AlignCenter, the default, AlignLeft, or AlignRight.
+func WithBadgeAlign(align BadgeAlign) Option {
+	return optionFunc(func(cfg *config) error {
+		return setBadgeAlign("WithBadgeAlign", align, &cfg.badgeAlign)
+	})
+}
+
+// setBadgeAlign validates align and stores it in dest, naming the offending option on failure.
+func setBadgeAlign(name string, align BadgeAlign, dest *BadgeAlign) error {
+	if align.String() == "unknown" {
+		return fmt.Errorf("%s: unknown badge alignment %d", name, align)
+	}
+	*dest = align
+	return nil
+}
+
+// WithIcons selects whether level badges in pretty output start with an icon:
+// IconsNerdFont or IconsASCII force a set, IconsAuto picks one from the
+// environment, and IconsOff, the default, leaves them out. Structured formats
+// never include icons.
+func WithIcons(mode IconMode) Option {
+	return optionFunc(func(cfg *config) error {
+		return setIconMode("WithIcons", mode, &cfg.icons)
+	})
+}
+
+// WithCompact turns compact mode on or off: each level is drawn as a single
+// colored symbol in place of its badge. See BarkOptions.Compact.
+// Like every option, it can be given to AddWriterLogger to make a single sink compact.
+func WithCompact(enabled bool) Option {
+	return optionFunc(func(cfg *config) error {
+		cfg.compact = enabled
+		return nil
+	})
+}
+
+// WithFatalBox turns boxed Fatal messages on or off. See BarkOptions.FatalBox.
+func WithFatalBox(enabled bool) Option {
+	return optionFunc(func(cfg *config) error {
+		cfg.fatalBox = enabled
+		return nil
+	})
+}
+
+// WithHighlightArgs turns highlighting of the values in formatted messages on or off.
+// See BarkOptions.HighlightArgs.
+func WithHighlightArgs(enabled bool) Option {
+	return optionFunc(func(cfg *config) error {
+		cfg.highlightArgs = enabled
+		return nil
+	})
+}
+
+// WithHighlightStyle sets the style of highlighted values in formatted messages,
+// bold by default, e.g. lipgloss.NewStyle().Foreground(lipgloss.Color("#8be9fd")).
+// It also turns highlighting on.
+func WithHighlightStyle(style lipgloss.Style) Option {
+	return optionFunc(func(cfg *config) error {
+		cfg.highlightArgs, cfg.highlightStyle = true, style
+		return nil
+	})
+}
+
+// WithTruncateToWidth turns clipping of messages to the terminal's width on or off.
+// See BarkOptions.TruncateToWidth. Give it to AddWriterLogger to clip a single sink,
+// e.g. a dashboard's terminal, while the others wrap.
+func WithTruncateToWidth(enabled bool) Option {
+	return optionFunc(func(cfg *config) error {
+		cfg.truncate = enabled
+		return nil
+	})
+}
+
+// WithCompactSymbol sets the symbol drawn for one of bark's own levels in
+// compact mode. Keep symbols one cell wide so entries line up.
+func WithCompactSymbol(level Level, symbol string) Option {
+	return optionFunc(func(cfg *config) error {
+		if _, ok := compactSymbols[level]; !ok {
+			return fmt.Errorf("WithCompactSymbol: level %s has no badge to replace", levelName(level))
+		}
+		if symbol == "" {
+			return fmt.Errorf("WithCompactSymbol: symbol for level %s is empty", levelName(level))
+		}
+
+		symbols := maps.Clone(cfg.compactSymbols)
+		if symbols == nil {
+			symbols = map[Level]string{}
+		}
+		symbols[level] = symbol
+		cfg.compactSymbols = symbols
+		return nil
+	})
+}
+
+// compactSymbol returns the symbol of level in compact mode.
+func (cfg config) compactSymbol(level Level) string {
+	if symbol, ok := cfg.compactSymbols[level]; ok {
+		return symbol
+	}
+	return compactSymbols[level]
+}
+
+// setIconMode validates mode and stores it in dest, naming the offending option on failure.
+func setIconMode(name string, mode IconMode, dest *IconMode) error {
+	if mode.String() == "unknown" {
+		return fmt.Errorf("%s: unknown icon mode %d", name, mode)
+	}
+	*dest = mode
+	return nil
+}
+
+// setFieldColor validates hex and stores it in hexDest, with a style coloring
+// text with it in styleDest, naming the offending option on failure.
+func setFieldColor(name, hex string, hexDest *string, styleDest *lipgloss.Style) error {
+	if err := setColor(name, hex, hexDest); err != nil {
+		return err
+	}
+	*styleDest = lipgloss.NewStyle().Foreground(lipgloss.Color(*hexDest))
+	return nil
+}
+
+// setFormat validates format and stores it in dest, naming the offending option on failure.
+func setFormat(name string, format Format, dest *Format) error {
+	if format.String() == "unknown" {
+		return fmt.Errorf("%s: unknown format %d", name, format)
+	}
+	*dest = format
+	return nil
+}
+
+// setCallerSkip validates n and stores it in dest, naming the offending option on failure.
+func setCallerSkip(name string, n int, dest *int) error {
+	if n < 0 {
+		return fmt.Errorf("%s: %d is negative", name, n)
+	}
+	*dest = n
+	return nil
+}
+
+// setBadgeColor validates hex and stores it in dest as the color for every
+// background, clearing the light variant in light, naming the offending option on failure.
+func setBadgeColor(name, hex string, dest, light *string) error {
+	if err := setColor(name, hex, dest); err != nil {
+		return err
+	}
+	*light = ""
+	return nil
+}
+
+// setColor validates color and stores it in dest in its normalized form,
+// naming the offending option on failure.
+func setColor(name, color string, dest *string) error {
+	normalized, ok := normalizeColor(color)
+	if !ok {
+		return fmt.Errorf("%s: %q is not a #RGB or #RRGGBB color, an ANSI index from 0 to 255, or a color name", name, color)
+	}
+	*dest = normalized
+	return nil
+}
+
+// normalizeColor returns color as a hex string or ANSI index,
+// resolving color names, and reports whether color is valid.
+func normalizeColor(color string) (string, bool) {
+	color = strings.TrimSpace(color)
+
+	if isHexColor(color) {
+		return color, true
+	}
+	if _, ok := ansiIndex(color); ok {
+		return color, true
+	}
+	if hex, ok := colorNames[strings.ToLower(color)]; ok {
+		return hex, true
+	}
+	return "", false
+}
+
+// isHexColor reports whether s is a color of the form #RGB or #RRGGBB.
+func isHexColor(s string) bool {
+	digits, ok := strings.CutPrefix(s, "#")
+	if !ok || (len(digits) != 3 && len(digits) != 6) {
+		return false
+	}
+
+	for _, r := range digits {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ansiIndex returns the palette index s names, if it is a decimal number from 0 to 255.
+func ansiIndex(s string) (int, bool) {
+	if s == "" || strings.TrimLeft(s, "0123456789") != "" {
+		return 0, false
+	}
+	i, err := strconv.Atoi(s)
+	if err != nil || i > 255 {
+		return 0, false
+	}
+	return i, true
+}
+
+// validateTimeFormat checks that layout contains at least one time element
+// and that a timestamp formatted with it can be parsed back.
+func validateTimeFormat(layout string) error {
+	ref := time.Date(2001, time.February, 3, 4, 5, 6, 0, time.UTC)
+	formatted := ref.Format(layout)
+
+	if formatted == layout {
+		return fmt.Errorf("%q contains no time elements", layout)
+	}
+
+	if _, err := time.Parse(layout, formatted); err != nil {
+		return fmt.Errorf("%q does not round-trip: %v", layout, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package bark_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"go.dalton.dog/bark"
+)
+
+func TestSetDefaultOptionsDoesNotRestyleExistingSinks(t *testing.T) {
+	t.Cleanup(func() { bark.Reset() })
+
+	// The escape sequences for the default Info color, #1982c4 as rendered, and the new one.
+	const (
+		oldColor = "38;2;25;130;195"
+		newColor = "38;2;0;255;0"
+	)
+
+	var buf bytes.Buffer
+	opts := []bark.Option{
+		bark.WithOutput(&buf),
+		bark.WithColor(bark.ColorAlways),
+		bark.WithColorProfile(bark.ProfileTrueColor),
+	}
+	if err := bark.Init(opts...); err != nil {
+		t.Fatal(err)
+	}
+	named := bark.GetLogger("svc")
+
+	if err := bark.SetDefaultOptions(bark.BarkOptions{InfoHex: "#00ff00"}); err != nil {
+		t.Fatal(err)
+	}
+
+	bark.Info("before init")
+	named.Info("before init")
+	if out := buf.String(); !strings.Contains(out, oldColor) || strings.Contains(out, newColor) {
+		t.Errorf("existing sinks were restyled by SetDefaultOptions:\n%q", out)
+	}
+
+	buf.Reset()
+	if err := bark.Init(opts...); err != nil {
+		t.Fatal(err)
+	}
+
+	bark.Info("after init")
+	named.Info("after init")
+	if out := buf.String(); strings.Contains(out, oldColor) || strings.Count(out, newColor) != 2 {
+		t.Errorf("the next Init didn't apply the new defaults to every Logger:\n%q", out)
+	}
+}
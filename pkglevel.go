@@ -0,0 +1,158 @@
+package bark
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	packageRulesMu sync.RWMutex
+	packageRules   []levelRule
+	// hasPackageRules lets log calls skip resolving their caller when there are no rules.
+	hasPackageRules atomic.Bool
+
+	// callSites caches, by program counter, the package of the function making
+	// a call into bark, or "" if that function is bark's own or a helper.
+	callSites sync.Map
+
+	// callerPackages caches, by program counter, the package of the first
+	// frame at that counter outside bark and helpers, or "" if there is none.
+	callerPackages sync.Map
+)
+
+// SetPackageLevel sets the level for log calls made from packages matching
+// pattern, identified by the import path of the calling code, so Debug can be
+// enabled for one part of a program without using named Loggers:
+//
+//	bark.SetPackageLevel("github.com/me/app/db", bark.DebugLevel)
+//	bark.SetPackageLevel("github.com/me/app/internal/*", bark.WarnLevel)
+//
+// A pattern is either an exact import path or a prefix followed by "*". When
+// several patterns match, the most recently set one wins. Package levels take the
+// place of the global level, so Loggers with their own level are unaffected.
+//
+// The calling package is resolved from a single stack frame and cached per call
+// site, so after the first call from a site the overhead is a map lookup; while
+// no package levels are set, it isn't resolved at all.
+func SetPackageLevel(pattern string, level Level) {
+	packageRulesMu.Lock()
+	packageRules = withoutRule(packageRules, pattern)
+	packageRules = append(packageRules, levelRule{pattern: pattern, level: level})
+	hasPackageRules.Store(true)
+	packageRulesMu.Unlock()
+
+	updateMinLevel()
+}
+
+// ClearPackageLevels removes every rule set by SetPackageLevel.
+func ClearPackageLevels() {
+	packageRulesMu.Lock()
+	packageRules = nil
+	hasPackageRules.Store(false)
+	packageRulesMu.Unlock()
+
+	updateMinLevel()
+}
+
+// packageLevel returns the level set with SetPackageLevel for the package of
+// the code calling into bark, if any rule matches it. skip is the number of
+// frames from packageLevel's caller to that code, as for runtime.Caller.
+func packageLevel(skip int) (Level, bool) {
+	if !hasPackageRules.Load() {
+		return 0, false
+	}
+
+	pkg := callerPackage(skip + 1)
+	if pkg == "" {
+		return 0, false
+	}
+
+	packageRulesMu.RLock()
+	defer packageRulesMu.RUnlock()
+
+	for i := len(packageRules) - 1; i >= 0; i-- {
+		if matchName(packageRules[i].pattern, pkg) {
+			return packageRules[i].level, true
+		}
+	}
+	return 0, false
+}
+
+// callerPackage returns the import path of the code calling into bark, skip
+// frames up from callerPackage's caller. When the function there is bark's own
+// or a helper, e.g. for calls through a wrapper, it falls back to the first
+// function on the stack outside bark and helpers.
+func callerPackage(skip int) string {
+	// One more frame for callerPackage itself.
+	pc, _, _, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return ""
+	}
+	if pkg := sitePackage(pc); pkg != "" {
+		return pkg
+	}
+
+	const maxDepth = 64
+	var pcs [maxDepth]uintptr
+	// Skip runtime.Callers and callerPackage.
+	n := runtime.Callers(2, pcs[:])
+
+	for _, pc := range pcs[:n] {
+		if pkg := pcPackage(pc); pkg != "" {
+			return pkg
+		}
+	}
+	return ""
+}
+
+// sitePackage returns the package of the function at pc, as returned by runtime.Caller,
+// or "" if it is bark's own or a helper.
+func sitePackage(pc uintptr) string {
+	if pkg, ok := callSites.Load(pc); ok {
+		return pkg.(string)
+	}
+
+	pkg := ""
+	if fn := runtime.FuncForPC(pc); fn != nil && !isSkippedFrame(fn.Name()) {
+		pkg = funcPackage(fn.Name())
+	}
+
+	callSites.Store(pc, pkg)
+	return pkg
+}
+
+// pcPackage returns the package of the first function at pc, innermost first
+// when functions were inlined, that is outside bark and helpers, or "" if there is none.
+func pcPackage(pc uintptr) string {
+	if pkg, ok := callerPackages.Load(pc); ok {
+		return pkg.(string)
+	}
+
+	pkg := ""
+	frames := runtime.CallersFrames([]uintptr{pc})
+	for {
+		frame, more := frames.Next()
+		if !isSkippedFrame(frame.Function) {
+			pkg = funcPackage(frame.Function)
+			break
+		}
+		if !more {
+			break
+		}
+	}
+
+	callerPackages.Store(pc, pkg)
+	return pkg
+}
+
+// funcPackage returns the import path of fn, a fully qualified function name
+// such as "github.com/me/app/db.(*Store).Get".
+func funcPackage(fn string) string {
+	slash := strings.LastIndex(fn, "/") + 1
+	if dot := strings.Index(fn[slash:], "."); dot >= 0 {
+		return fn[:slash+dot]
+	}
+	return fn
+}
@@ -0,0 +1,32 @@
+package bark
+
+import "sync/atomic"
+
+// quiet is set with SetQuiet or the Quiet option.
+var quiet atomic.Bool
+
+// SetQuiet turns quiet mode on or off, as for a -q flag. In quiet mode, sinks
+// writing to the terminal (standard error or standard output) only show Error
+// and Fatal entries, whatever the level, while other sinks such as files keep
+// the full record. Print output is still shown, since it is the program's
+// output rather than logging. Quiet mode can be changed at any time.
+func SetQuiet(v bool) {
+	quiet.Store(v)
+}
+
+// terminal reports whether s writes to standard error or standard output.
+// s.mu must be held.
+func (s *sink) terminal() bool {
+	return s.kind == "stderr" || s.kind == "stdout"
+}
+
+// quieted reports whether quiet mode keeps s from writing an entry at level.
+func (s *sink) quieted(level Level) bool {
+	if level >= ErrorLevel || !quiet.Load() {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.terminal()
+}
@@ -0,0 +1,46 @@
+package bark
+
+import (
+	"slices"
+	"sync"
+)
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]*Logger{}
+)
+
+// GetLogger returns the named Logger, creating it on first use.
+// Named Loggers use their name as a prefix and share the sinks configured by Init,
+// so reconfiguring bark later applies to every Logger already handed out.
+// Repeated calls with the same name return the same instance.
+// Until its SetLevel is called, its level follows any matching SetLevelFor rule,
+// or the global level otherwise.
+func GetLogger(name string) *Logger {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if logger, ok := registry[name]; ok {
+		return logger
+	}
+
+	logger := &Logger{prefix: []string{name}, level: &levelVar{}}
+	applyLevelRule(name, logger)
+	registry[name] = logger
+
+	return logger
+}
+
+// ListLoggers returns the names of every Logger created by GetLogger, sorted.
+func ListLoggers() []string {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
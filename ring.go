@@ -0,0 +1,80 @@
+package bark
+
+import (
+	"slices"
+	"sync"
+)
+
+var (
+	ringMu sync.Mutex
+	// ring holds the most recent entries, oldest at ringNext once it is full,
+	// or is nil when no entries are being kept.
+	ring     []CapturedEntry
+	ringNext int
+	ringFull bool
+)
+
+// EnableRingBuffer starts keeping the size most recent entries in memory, so
+// they can be read with RingBufferEntries after an incident even if the log
+// file has since been rotated. Entries are kept by a post hook, run inline in
+// registration order with the others, so only those that passed level filtering
+// and filters are kept. Calling it again discards the entries kept so far; a size
+// below 1 is the same as DisableRingBuffer.
+func EnableRingBuffer(size int) {
+	if size < 1 {
+		DisableRingBuffer()
+		return
+	}
+
+	ringMu.Lock()
+	defer ringMu.Unlock()
+	if ring == nil {
+		addPostHook(postHook{fn: recordRecent, inline: true, ring: true})
+	}
+	ring = make([]CapturedEntry, size)
+	ringNext, ringFull = 0, false
+}
+
+// DisableRingBuffer stops keeping recent entries, removing the post hook that
+// keeps them, and releases the ones kept so far.
+func DisableRingBuffer() {
+	ringMu.Lock()
+	defer ringMu.Unlock()
+	removePostHooks(func(hook postHook) bool { return hook.ring })
+	ring = nil
+	ringNext, ringFull = 0, false
+}
+
+// ringBufferEnabled reports whether recent entries are being kept.
+func ringBufferEnabled() bool {
+	ringMu.Lock()
+	defer ringMu.Unlock()
+	return ring != nil
+}
+
+// recordRecent is the post hook installed by EnableRingBuffer. It keeps an entry in
+// the ring buffer, if still enabled, replacing the oldest when full.
+func recordRecent(level Level, msg string, fields []any) {
+	ringMu.Lock()
+	defer ringMu.Unlock()
+	if ring == nil {
+		return
+	}
+
+	// Copy fields, which post hooks may still modify.
+	ring[ringNext] = CapturedEntry{Level: level, Message: msg, Fields: slices.Clone(fields)}
+	ringNext = (ringNext + 1) % len(ring)
+	ringFull = ringFull || ringNext == 0
+}
+
+// RingBufferEntries returns a copy of the entries kept by EnableRingBuffer, oldest
+// first, or nil if it isn't enabled.
+func RingBufferEntries() []CapturedEntry {
+	ringMu.Lock()
+	defer ringMu.Unlock()
+
+	if !ringFull {
+		return append([]CapturedEntry(nil), ring[:ringNext]...)
+	}
+	return append(append([]CapturedEntry(nil), ring[ringNext:]...), ring[:ringNext]...)
+}
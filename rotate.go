@@ -0,0 +1,37 @@
+package bark
+
+import "errors"
+
+// FileRotator is implemented by sink writers that can rotate the file they write to,
+// such as the RotatingFile that AddFileLogger writes to.
+type FileRotator interface {
+	// Rotate closes the current file and starts a new one, returning the names
+	// of the file it closed and of the one it opened.
+	Rotate() (oldName, newName string, err error)
+}
+
+// RotateFiles rotates every sink whose writer implements FileRotator, logging
+// the old and new file names of each at Info level, shown whatever the global
+// level, and returns their errors joined. Bark writes to the new files afterwards
+// without any further action, since the writers themselves switch files.
+func RotateFiles() error {
+	current := currentSinks()
+
+	var errs []error
+	for _, s := range current {
+		r, ok := s.writer().(FileRotator)
+		if !ok {
+			continue
+		}
+
+		oldName, newName, err := r.Rotate()
+		if err != nil {
+			writeAll(current, ErrorLevel, "log file rotation failed", "error", err)
+			errs = append(errs, err)
+			continue
+		}
+		writeAll(current, InfoLevel, "log file rotated", "old_file", oldName, "new_file", newName)
+	}
+
+	return errors.Join(errs...)
+}
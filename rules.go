@@ -0,0 +1,146 @@
+package bark
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// levelRule assigns a level to every named Logger matching pattern.
+type levelRule struct {
+	pattern string
+	level   Level
+}
+
+var (
+	rulesMu    sync.RWMutex
+	levelRules []levelRule
+)
+
+// SetLevelFor sets the level for named Loggers matching pattern, overriding the
+// global level for them. A pattern is either an exact name ("db") or a prefix
+// followed by "*" ("http*"). The rule applies immediately to matching Loggers
+// already created by GetLogger and is remembered for ones created later.
+// When several rules match a name, the most recently set one wins. A level set
+// on a Logger with its SetLevel method takes precedence over every rule.
+func SetLevelFor(pattern string, level Level) {
+	rulesMu.Lock()
+	levelRules = withoutRule(levelRules, pattern)
+	levelRules = append(levelRules, levelRule{pattern: pattern, level: level})
+	rulesMu.Unlock()
+
+	updateMinLevel()
+	applyLevelRules()
+}
+
+// SetLevelRules parses a comma-separated spec such as "db=debug,http*=warn"
+// and applies each entry as if passed to SetLevelFor. An entry without "=",
+// such as "warn", sets the global level instead. The whole spec is validated
+// before anything is applied.
+func SetLevelRules(spec string) error {
+	var (
+		rules       []levelRule
+		global      Level
+		hasGlobal   bool
+		invalidErrs []string
+	)
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		pattern, levelName, hasPattern := strings.Cut(entry, "=")
+		if !hasPattern {
+			levelName = pattern
+		}
+
+		level, err := ParseLevel(strings.TrimSpace(levelName))
+		if err != nil {
+			invalidErrs = append(invalidErrs, fmt.Sprintf("%q: %v", entry, err))
+			continue
+		}
+
+		if hasPattern {
+			rules = append(rules, levelRule{pattern: strings.TrimSpace(pattern), level: level})
+		} else {
+			global, hasGlobal = level, true
+		}
+	}
+
+	if len(invalidErrs) > 0 {
+		return fmt.Errorf("invalid level rules: %s", strings.Join(invalidErrs, "; "))
+	}
+
+	if hasGlobal {
+		SetLevel(global)
+	}
+
+	rulesMu.Lock()
+	for _, rule := range rules {
+		levelRules = withoutRule(levelRules, rule.pattern)
+		levelRules = append(levelRules, rule)
+	}
+	rulesMu.Unlock()
+
+	updateMinLevel()
+	applyLevelRules()
+
+	return nil
+}
+
+// ClearLevelRules removes every rule set by SetLevelFor or SetLevelRules,
+// returning named Loggers to the global level, or to the level set with their
+// SetLevel method, if any.
+func ClearLevelRules() {
+	rulesMu.Lock()
+	levelRules = nil
+	rulesMu.Unlock()
+
+	updateMinLevel()
+	applyLevelRules()
+}
+
+// applyLevelRules re-resolves the level of every registered Logger.
+func applyLevelRules() {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	for name, logger := range registry {
+		applyLevelRule(name, logger)
+	}
+}
+
+// applyLevelRule sets logger's rule level from the last rule matching name,
+// or removes it if no rule matches. A level set explicitly is left alone.
+func applyLevelRule(name string, logger *Logger) {
+	rulesMu.RLock()
+	defer rulesMu.RUnlock()
+
+	for i := len(levelRules) - 1; i >= 0; i-- {
+		if matchName(levelRules[i].pattern, name) {
+			logger.level.setRule(levelRules[i].level)
+			return
+		}
+	}
+
+	logger.level.unsetRule()
+}
+
+// matchName reports whether name matches pattern, where a trailing "*"
+// in pattern matches any suffix.
+func matchName(pattern, name string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(name, prefix)
+	}
+	return pattern == name
+}
+
+// withoutRule returns rules without any entry for pattern.
+func withoutRule(rules []levelRule, pattern string) []levelRule {
+	return slices.DeleteFunc(rules, func(rule levelRule) bool {
+		return rule.pattern == pattern
+	})
+}
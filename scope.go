@@ -0,0 +1,61 @@
+package bark
+
+import "sync"
+
+var (
+	scopesMu sync.RWMutex
+	scopes   [][]any
+)
+
+// PushFields adds a scope of keyvals attached to every log entry until the
+// matching PopFields. Scopes nest: fields from every pushed scope are attached,
+// and an inner scope's key takes precedence over the same key in an outer one.
+// Useful for tagging every entry during a phase of a program:
+//
+//	bark.PushFields("phase", "apply")
+//	defer bark.PopFields()
+func PushFields(keyvals ...any) {
+	scopesMu.Lock()
+	defer scopesMu.Unlock()
+	scopes = append(scopes, append([]any(nil), keyvals...))
+}
+
+// PopFields removes the most recently pushed scope.
+// Popping with no scope pushed logs a Warn instead of panicking.
+func PopFields() {
+	scopesMu.Lock()
+	if len(scopes) == 0 {
+		scopesMu.Unlock()
+		Warn("bark: PopFields called without a matching PushFields")
+		return
+	}
+	scopes = scopes[:len(scopes)-1]
+	scopesMu.Unlock()
+}
+
+// WithScope pushes keyvals as a scope, runs fn, and pops the scope again,
+// even if fn panics.
+func WithScope(fn func(), keyvals ...any) {
+	PushFields(keyvals...)
+	defer PopFields()
+	fn()
+}
+
+// scopeFields returns the keyvals of every pushed scope, outermost first.
+func scopeFields() []any {
+	scopesMu.RLock()
+	defer scopesMu.RUnlock()
+
+	var fields []any
+	for _, scope := range scopes {
+		fields = append(fields, scope...)
+	}
+	return fields
+}
+
+// clearScopes removes every pushed scope.
+func clearScopes() {
+	scopesMu.Lock()
+	defer scopesMu.Unlock()
+	scopes = nil
+}
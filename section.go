@@ -0,0 +1,81 @@
+package bark
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Section marks the start of a phase of a long run, such as "apply", with a rule
+// spanning the terminal's width and titled with title:
+//
+//	──── apply ─────────────────────────────────────────
+//
+// The rule takes the color set with WithSectionColor. Sinks that don't write to a
+// terminal get a plain "=== apply ===" line instead, and structured sinks an
+// entry with title as its message and a section=true field. Sections are shown
+// at Info level, so they are hidden along with Info entries, but they bypass
+// hooks and aren't counted by LogCounter.
+func Section(title string) {
+	std.section(title)
+}
+
+// Section marks the start of a phase of a long run. See the package-level Section.
+func (l *Logger) Section(title string) {
+	l.section(title)
+}
+
+// section writes title as a section to each of l's sinks.
+func (l *Logger) section(title string) {
+	// The code calling into bark is past Section.
+	if !l.enabled(InfoLevel, 2) || filtered(InfoLevel, title, nil) {
+		return
+	}
+
+	offset := -1
+	for _, s := range l.currentSinks() {
+		if s.quieted(InfoLevel) {
+			continue
+		}
+		if s.reportCaller && offset < 0 {
+			offset = callerOffset()
+		}
+		if s.format.structured() {
+			s.write(InfoLevel, l.prefix, title, nil, []any{"section", true}, offset)
+			continue
+		}
+
+		s.writeLine(InfoLevel, title, []any{"section", true}, func() string { return s.sectionRule(title) })
+	}
+}
+
+// sectionRule returns the line showing a section titled title on s:
+// a styled rule as wide as the terminal, or a plain marker off terminals.
+// s.mu must be held.
+func (s *sink) sectionRule(title string) string {
+	if !isTerminal(s.out) {
+		return strings.TrimSpace("=== " + title + " ===")
+	}
+
+	const lead = 4
+	width := lineWidth(s.out)
+
+	var rule string
+	if title == "" {
+		rule = strings.Repeat("─", width)
+	} else {
+		// Keep a few dashes after the title even when it doesn't fit.
+		rest := max(width-lead-lipgloss.Width(title)-2, lead)
+		rule = strings.Repeat("─", lead) + " " + title + " " + strings.Repeat("─", rest)
+	}
+	return s.sectionStyle.Renderer(s.renderer).Render(rule)
+}
+
+// sectionStyle returns the style of Section rules configured by cfg,
+// with colors picked for r's terminal.
+func sectionStyle(cfg config, r *lipgloss.Renderer) lipgloss.Style {
+	if cfg.sectionHex != "" {
+		return lipgloss.NewStyle().Foreground(badgeColor(cfg.sectionHex, "", r))
+	}
+	return lipgloss.NewStyle().Foreground(badgeColor(cfg.infoHex, cfg.infoLight, r))
+}
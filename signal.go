@@ -0,0 +1,26 @@
+package bark
+
+// levelSteps is the sequence of levels EnableSignalLevelControl steps
+// through, from most to least verbose.
+var levelSteps = []Level{TraceLevel, DebugLevel, InfoLevel, WarnLevel, ErrorLevel, FatalLevel}
+
+// stepLevel returns the level one step more verbose than level when louder is true,
+// or one step less verbose otherwise, stopping at either end of levelSteps.
+// Levels between two steps, such as Notice, move to the nearer step in that direction.
+func stepLevel(level Level, louder bool) Level {
+	if louder {
+		for i := len(levelSteps) - 1; i >= 0; i-- {
+			if levelSteps[i] < level {
+				return levelSteps[i]
+			}
+		}
+		return levelSteps[0]
+	}
+
+	for _, step := range levelSteps {
+		if step > level {
+			return step
+		}
+	}
+	return levelSteps[len(levelSteps)-1]
+}
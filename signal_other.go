@@ -0,0 +1,23 @@
+//go:build !unix
+
+package bark
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// EnableSignalLevelControl lets the global level be changed with SIGUSR1 and SIGUSR2
+// on Unix. Other platforms, such as Windows, don't have those signals, so it
+// returns an error and a no-op cancel function.
+func EnableSignalLevelControl() (cancel func(), err error) {
+	return func() {}, fmt.Errorf("EnableSignalLevelControl: SIGUSR1 and SIGUSR2 are not supported on %s", runtime.GOOS)
+}
+
+// RegisterSignalHandler makes SIGUSR1 toggle Debug logging on Unix. Other
+// platforms, such as Windows, don't have that signal, so it does nothing.
+func RegisterSignalHandler() {}
+
+// RegisterRotationSignal makes SIGUSR2 rotate log files on Unix. Other
+// platforms, such as Windows, don't have that signal, so it does nothing.
+func RegisterRotationSignal() {}
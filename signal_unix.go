@@ -0,0 +1,115 @@
+//go:build unix
+
+package bark
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// EnableSignalLevelControl lets the global level be changed without restarting
+// the program: SIGUSR1 makes logging one step more verbose (e.g. Info to Debug)
+// and SIGUSR2 one step less, through Trace, Debug, Info, Warn, Error, and Fatal.
+// Each change is confirmed with an Info entry that is shown whatever the new level.
+//
+// Calling cancel stops handling the signals and restores their default behavior.
+// On platforms without SIGUSR1 and SIGUSR2, such as Windows, it returns an error.
+func EnableSignalLevelControl() (cancel func(), err error) {
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, syscall.SIGUSR1, syscall.SIGUSR2)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case sig := <-signals:
+				level := stepLevel(Level(globalLevel.Load()), sig == syscall.SIGUSR1)
+				SetLevel(level)
+				writeAll(currentSinks(), InfoLevel, "log level changed", "new_level", levelName(level), "signal", sig.String())
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	cancel = func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(done)
+			wg.Wait()
+		})
+	}
+
+	return cancel, nil
+}
+
+var (
+	debugToggleOnce sync.Once
+	// debugToggleRestore is the level a second SIGUSR1 returns to; only the handler goroutine uses it.
+	debugToggleRestore Level
+)
+
+// RegisterSignalHandler makes SIGUSR1 toggle Debug logging without restarting the
+// program: the first signal sets the global level to Debug, and the next one
+// restores the level from before, and so on. Each change is confirmed with an
+// Info entry that is shown whatever the new level.
+//
+// Calling it more than once has no further effect. Use either it or
+// EnableSignalLevelControl, which also handles SIGUSR1; on platforms without
+// SIGUSR1, such as Windows, it does nothing.
+func RegisterSignalHandler() {
+	debugToggleOnce.Do(func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGUSR1)
+
+		go func() {
+			for sig := range signals {
+				level := toggleDebugLevel()
+				writeAll(currentSinks(), InfoLevel, "log level changed", "new_level", levelName(level), "signal", sig.String())
+			}
+		}()
+	})
+}
+
+// toggleDebugLevel sets the global level to Debug, remembering the current one,
+// or restores the remembered level if it already is Debug. It returns the new level.
+func toggleDebugLevel() Level {
+	current := Level(globalLevel.Load())
+	if current == DebugLevel {
+		level := debugToggleRestore
+		if level == DebugLevel {
+			level = InfoLevel
+		}
+		SetLevel(level)
+		return level
+	}
+
+	debugToggleRestore = current
+	SetLevel(DebugLevel)
+	return DebugLevel
+}
+
+var rotationSignalOnce sync.Once
+
+// RegisterRotationSignal makes SIGUSR2 rotate log files, such as those added by
+// AddFileLogger, as RotateFiles does, so that a script can rotate them with kill -USR2. Calling it more than once
+// has no further effect. Use either it or EnableSignalLevelControl, which also
+// handles SIGUSR2; on platforms without SIGUSR2, such as Windows, it does nothing.
+func RegisterRotationSignal() {
+	rotationSignalOnce.Do(func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGUSR2)
+
+		go func() {
+			for range signals {
+				RotateFiles()
+			}
+		}()
+	})
+}
@@ -0,0 +1,424 @@
+package bark
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"github.com/muesli/termenv"
+)
+
+// sink is a destination for log entries: an underlying logger,
+// the writer it was created with, and how it renders entries.
+type sink struct {
+	logger *log.Logger
+	out    io.Writer
+	kind   string
+	format Format
+	color  bool
+
+	// counter sits between logger and out, counting bytes for BytesWritten.
+	counter *countingWriter
+
+	// measure receives entries rendered only to measure them, for clipping.
+	measure bytes.Buffer
+
+	// colorMode and colorProfile are the configured ColorMode and ColorProfile,
+	// kept to re-evaluate color when the writer changes. Badge colors stay picked
+	// for the original writer's background and profile; the renderer degrades them
+	// further if needed.
+	colorMode    ColorMode
+	colorProfile ColorProfile
+
+	// messageStyles style the message text of pretty entries by level,
+	// rendered for the sink's writer by renderer.
+	messageStyles map[Level]lipgloss.Style
+	renderer      *lipgloss.Renderer
+
+	// prefixStyle and prefixSeparator render each prefix segment of pretty entries.
+	prefixStyle     lipgloss.Style
+	prefixSeparator string
+
+	// sectionStyle styles the rules Section draws on terminals.
+	sectionStyle lipgloss.Style
+
+	// fatalBox, if set, is the style of the box Fatal messages are drawn in on terminals.
+	fatalBox *lipgloss.Style
+	// highlight, if set, is the style of the values in messages logged with *f methods.
+	highlight *lipgloss.Style
+	// truncate clips pretty messages written to terminals to the terminal's width.
+	truncate bool
+
+	// styles are the logger's styles, replaced as a whole when a level is registered.
+	styles *log.Styles
+
+	reportCaller bool
+	callerSkip   int
+
+	// capture, if set, also records each entry for tests.
+	capture *TestCapture
+
+	// mu serializes writes, which set the counter's level and the logger's caller
+	// offset for each entry, and changes to styles and the writer.
+	mu sync.Mutex
+}
+
+// newSink creates a sink writing to w, styled and formatted according to cfg.
+func newSink(w io.Writer, cfg config) *sink {
+	counter := &countingWriter{w: w}
+	logger := log.New(counter)
+	styles := log.DefaultStyles()
+
+	// Colors are picked and mapped for w itself, which the counter hides from logger.
+	profile := colorProfile(w, cfg.color, cfg.colorProfile)
+	renderer := lipgloss.NewRenderer(w)
+	renderer.SetColorProfile(profile)
+	setLevelStyles(styles, cfg, renderer)
+	setFieldStyles(styles, cfg, renderer)
+	logger.SetColorProfile(profile)
+
+	logger.SetStyles(styles)
+	logger.SetTimeFormat(cfg.timeFormat)
+	logger.SetTimeFunction(clockTime)
+	logger.SetReportTimestamp(cfg.timeFormat != "" && !cfg.format.enveloped())
+	logger.SetLevel(passAllLevel)
+	logger.SetFormatter(cfg.format.formatter())
+
+	logger.SetReportCaller(cfg.reportCaller)
+	if cfg.format.structured() {
+		logger.SetCallerFormatter(log.LongCallerFormatter)
+	} else {
+		logger.SetCallerFormatter(log.ShortCallerFormatter)
+	}
+
+	return &sink{
+		logger:       logger,
+		out:          w,
+		counter:      counter,
+		kind:         writerKind(w),
+		format:       cfg.format,
+		color:        !cfg.format.structured() && profile != termenv.Ascii,
+		colorMode:    cfg.color,
+		colorProfile: cfg.colorProfile,
+		styles:       styles,
+
+		messageStyles: cfg.messageStyles,
+		renderer:      renderer,
+
+		prefixStyle:     cfg.prefixStyle,
+		prefixSeparator: cfg.prefixSeparator,
+
+		sectionStyle: sectionStyle(cfg, renderer),
+		fatalBox:     fatalBoxStyle(cfg, renderer),
+		highlight:    highlightStyle(cfg),
+		truncate:     cfg.truncate && !cfg.format.structured(),
+
+		reportCaller: cfg.reportCaller,
+		callerSkip:   cfg.callerSkip,
+	}
+}
+
+// AddWriterLogger adds a sink writing to w alongside those configured by Init,
+// e.g. to also write JSON to a file. The sink starts from the configuration of
+// the last Init, with opts applied on top; w takes the place of any WithOutput.
+// A later Init replaces every sink, including ones added here.
+func AddWriterLogger(w io.Writer, opts ...Option) error {
+	if w == nil {
+		return fmt.Errorf("AddWriterLogger: writer is nil")
+	}
+
+	// Make sure the sinks from Init, or the defaults, are in place first.
+	currentSinks()
+
+	sinksMu.Lock()
+	defer sinksMu.Unlock()
+
+	cfg, err := activeConfig.with(opts...)
+	if err != nil {
+		return err
+	}
+
+	// Copy rather than append in place, since callers iterate over snapshots.
+	sinks = append(slices.Clip(sinks), newSink(w, cfg))
+	updateMinLevel()
+
+	return nil
+}
+
+// writerKind describes w for introspection.
+func writerKind(w io.Writer) string {
+	switch w {
+	case os.Stderr:
+		return "stderr"
+	case os.Stdout:
+		return "stdout"
+	}
+	if _, ok := w.(captureWriter); ok {
+		return "capture"
+	}
+	if k, ok := w.(kindWriter); ok {
+		return k.sinkKind()
+	}
+	if _, ok := w.(*os.File); ok {
+		return "file"
+	}
+	return "writer"
+}
+
+// kindWriter is implemented by writers of bark's own that describe themselves for introspection.
+type kindWriter interface {
+	sinkKind() string
+}
+
+// entryWriter is implemented by writers of bark's own that handle entries by level,
+// such as syslog's. Sinks call startEntry before writing an entry and endEntry after.
+type entryWriter interface {
+	startEntry(level Level)
+	endEntry()
+}
+
+// clone returns a copy of s with its own underlying logger, writing to the same writer.
+func (s *sink) clone() *sink {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counter := &countingWriter{w: s.out}
+	logger := s.logger.With()
+	logger.SetOutput(counter)
+	logger.SetColorProfile(s.renderer.ColorProfile())
+
+	return &sink{
+		logger:       logger,
+		out:          s.out,
+		counter:      counter,
+		kind:         s.kind,
+		format:       s.format,
+		color:        s.color,
+		colorMode:    s.colorMode,
+		colorProfile: s.colorProfile,
+		styles:       s.styles,
+
+		messageStyles: s.messageStyles,
+		renderer:      s.renderer,
+
+		prefixStyle:     s.prefixStyle,
+		prefixSeparator: s.prefixSeparator,
+
+		sectionStyle: s.sectionStyle,
+		fatalBox:     s.fatalBox,
+		highlight:    s.highlight,
+		truncate:     s.truncate,
+
+		reportCaller: s.reportCaller,
+		callerSkip:   s.callerSkip,
+		capture:      s.capture,
+	}
+}
+
+// setOutput makes s write to w, re-detecting whether w is a terminal
+// that supports colors, so that output to files and pipes is left unstyled.
+func (s *sink) setOutput(w io.Writer) {
+	profile := colorProfile(w, s.colorMode, s.colorProfile)
+	renderer := lipgloss.NewRenderer(w)
+	renderer.SetColorProfile(profile)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.counter.w = w
+	s.out = w
+	s.kind = writerKind(w)
+	s.logger.SetColorProfile(profile)
+	s.renderer = renderer
+	s.color = !s.format.structured() && profile != termenv.Ascii
+}
+
+// writer returns the writer s currently writes to.
+func (s *sink) writer() io.Writer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.out
+}
+
+// write sends one entry to s. prefix holds the segments of the Logger's prefix, if any,
+// and args, if set, the format and values msg was formatted from. offset is the
+// caller offset, as returned by callerOffset, of the function calling write;
+// it is only used when s reports callers.
+func (s *sink) write(level Level, prefix []string, msg string, args *formatArgs, fields []any, offset int) {
+	if s.capture != nil {
+		s.capture.record(level, msg, fields)
+	}
+
+	// Rendering reads the writer and renderer, which setOutput may replace, and
+	// the counter's level, and the shared logger's caller offset, must not change mid-write.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Bytes are counted under the entry's own level, before any adaptation for the format.
+	entryLevel := level
+	var box string
+
+	if s.format.structured() {
+		if s.format.enveloped() {
+			fields = s.format.envelope(now(), level, msg, fields)
+			msg = ""
+		} else {
+			level, fields = structuredLevel(level, fields)
+		}
+	} else {
+		// A boxed message goes below the entry's line, which keeps the badge and fields.
+		if level == FatalLevel && s.fatalBox != nil && isTerminal(s.out) {
+			box = s.renderFatalBox(msg)
+			msg = ""
+		} else {
+			msg = s.renderMessage(level, msg, args)
+		}
+		if len(prefix) > 0 {
+			msg = s.renderPrefix(prefix) + " " + msg
+		}
+	}
+
+	// Structured formats write the prefix unstyled, as a field; pretty ones have it in msg.
+	logger := s.logger
+	if len(prefix) > 0 && s.format.structured() {
+		logger = logger.WithPrefix(strings.Join(prefix, "."))
+	}
+
+	s.counter.level = entryLevel
+
+	if ew, ok := s.out.(entryWriter); ok {
+		ew.startEntry(entryLevel)
+		defer ew.endEntry()
+	}
+
+	if s.reportCaller {
+		// One more frame for write itself.
+		logger.SetCallerOffset(offset + 1 + s.callerSkip)
+	}
+
+	// To clip the message, the entry is first rendered around a marker by a copy
+	// of the logger writing to s.measure, to measure the rest of its line.
+	if s.truncate && msg != "" && isTerminal(s.out) {
+		s.measure.Reset()
+		measurer := logger.With()
+		measurer.SetOutput(&s.measure)
+		measurer.Log(level, clipMarker, fields...)
+		msg = s.clipMessage(msg, s.measure.String())
+	}
+	logger.Log(level, msg, fields...)
+
+	if box != "" {
+		io.WriteString(s.counter, box+"\n")
+	}
+}
+
+// renderMessage renders msg, logged at level, in the level's message style, if any,
+// highlighting the values it was formatted from if s highlights them and args is set.
+func (s *sink) renderMessage(level Level, msg string, args *formatArgs) string {
+	var style *lipgloss.Style
+	if st, ok := s.messageStyles[level]; ok {
+		style = &st
+	}
+
+	if s.highlight != nil && args != nil {
+		if highlighted, ok := s.renderHighlighted(style, msg, args); ok {
+			return highlighted
+		}
+	}
+	if style != nil {
+		return renderMessage(s.renderer, *style, msg)
+	}
+	return msg
+}
+
+// renderPrefix renders each segment of prefix with s's prefix style,
+// followed by its separator, separating the segments with spaces.
+func (s *sink) renderPrefix(prefix []string) string {
+	style := s.prefixStyle.Renderer(s.renderer)
+
+	segments := make([]string, len(prefix))
+	for i, segment := range prefix {
+		segments[i] = style.Render(segment + s.prefixSeparator)
+	}
+	return strings.Join(segments, " ")
+}
+
+// renderFatalBox renders msg in s's Fatal box, as wide as the terminal,
+// wrapping long lines at word boundaries and keeping msg's own line breaks.
+func (s *sink) renderFatalBox(msg string) string {
+	style := s.fatalBox.Renderer(s.renderer)
+	// The width excludes the border, one cell on each side.
+	return style.Width(lineWidth(s.out) - 2).Render(msg)
+}
+
+// print writes msg as program output: alone on its line for pretty sinks,
+// or as an ordinary entry at PrintLevel for structured ones. offset is as for write.
+func (s *sink) print(msg string, offset int) {
+	if s.format.structured() {
+		// One more frame for print itself.
+		s.write(PrintLevel, nil, msg, nil, nil, offset+1)
+		return
+	}
+
+	s.writeLine(PrintLevel, msg, nil, func() string { return msg })
+}
+
+// writeLine writes the line returned by render to s's writer as is, on a line of
+// its own, for an entry at level with msg and fields, which are recorded like those
+// of written entries. Its bytes are counted under level. render is called with s
+// locked, so it may read s's writer and renderer. It is how pretty sinks show
+// entries bark renders itself.
+func (s *sink) writeLine(level Level, msg string, fields []any, render func() string) {
+	if s.capture != nil {
+		s.capture.record(level, msg, fields)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.counter.level = level
+	line := render()
+
+	if ew, ok := s.out.(entryWriter); ok {
+		ew.startEntry(level)
+		defer ew.endEntry()
+	}
+	io.WriteString(s.counter, line+"\n")
+}
+
+// writeAll writes an entry from bark itself to targets, bypassing level filtering,
+// filters, and hooks. Unlike logging through a Logger, it never waits on
+// auto-initialization, so it can be used while that is running.
+func writeAll(targets []*sink, level Level, msg string, keyvals ...any) {
+	for _, s := range targets {
+		s.write(level, nil, msg, nil, keyvals, 0)
+	}
+}
+
+// rawKey is a field key that the underlying formatters write as an ordinary
+// field, even when it matches one of the keys they reserve, such as "level".
+type rawKey string
+
+func (k rawKey) String() string {
+	return string(k)
+}
+
+// structuredLevel adapts level and fields for a structured format. Success is
+// reported as Info with a success=true field so parsers only see standard level
+// names; other levels bark defines are named in a "level" field.
+func structuredLevel(level Level, fields []any) (Level, []any) {
+	if level == SuccessLevel {
+		return InfoLevel, append(slices.Clip(fields), "success", true)
+	}
+
+	_, custom := customLevelNames[level]
+	if _, registered := registeredLevelName(level); custom || registered {
+		return level, append([]any{rawKey(log.LevelKey), levelName(level)}, fields...)
+	}
+
+	return level, fields
+}
@@ -0,0 +1,54 @@
+package bark_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"go.dalton.dog/bark"
+)
+
+func TestNonTerminalOutputHasNoEscapes(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("CLICOLOR", "")
+	t.Setenv("CLICOLOR_FORCE", "")
+
+	var buf bytes.Buffer
+	logger, err := bark.New(bark.WithOutput(&buf), bark.WithHighlightArgs(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger = logger.WithPrefix("db")
+	logger.SetLevel(bark.TraceLevel)
+
+	logEveryLevel(logger)
+	assertNoEscapes(t, buf.String())
+
+	// Swapping the writer re-detects it.
+	var swapped bytes.Buffer
+	logger.SetOutput(&swapped)
+
+	logEveryLevel(logger)
+	assertNoEscapes(t, swapped.String())
+}
+
+func logEveryLevel(logger *bark.Logger) {
+	logger.Trace("trace", "key", "value")
+	logger.Debug("debug", "key", "value")
+	logger.Infof("info %d", 42)
+	logger.Success("success", "key", "value")
+	logger.Notice("notice", "key", "value")
+	logger.Warn("warn", "key", "value")
+	logger.Error("error", "key", "value")
+}
+
+func assertNoEscapes(t *testing.T, out string) {
+	t.Helper()
+
+	if out == "" {
+		t.Fatal("nothing was written")
+	}
+	if strings.Contains(out, "\x1b") {
+		t.Errorf("output contains escape sequences:\n%q", out)
+	}
+}
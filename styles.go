@@ -0,0 +1,353 @@
+package bark
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"github.com/muesli/termenv"
+)
+
+// BadgeStyle selects how level badges are drawn in pretty output.
+type BadgeStyle int
+
+const (
+	// BadgeText draws the level name in the level's color. It is the default.
+	BadgeText BadgeStyle = iota
+	// BadgeBlock draws the level name in black or white, whichever contrasts
+	// better, on a block of the level's color.
+	BadgeBlock
+)
+
+// BadgeAlign selects where level names sit within their badges,
+// when the badges are wider than the names.
+type BadgeAlign int
+
+const (
+	// AlignCenter centers names in their badges. It is the default.
+	AlignCenter BadgeAlign = iota
+	// AlignLeft puts names at the start of their badges.
+	AlignLeft
+	// AlignRight puts names at the end of their badges.
+	AlignRight
+)
+
+// String returns the name of the alignment.
+func (a BadgeAlign) String() string {
+	switch a {
+	case AlignCenter:
+		return "center"
+	case AlignLeft:
+		return "left"
+	case AlignRight:
+		return "right"
+	default:
+		return "unknown"
+	}
+}
+
+// String returns the name of the badge style.
+func (b BadgeStyle) String() string {
+	switch b {
+	case BadgeText:
+		return "text"
+	case BadgeBlock:
+		return "block"
+	default:
+		return "unknown"
+	}
+}
+
+// defaultMessageStyles returns the message styles used unless replaced with
+// WithMessageStyle: Trace and Debug messages are faint so they recede,
+// and Error, Panic, and Fatal messages are bold so they stand out.
+func defaultMessageStyles() map[Level]lipgloss.Style {
+	faint := lipgloss.NewStyle().Faint(true)
+	bold := lipgloss.NewStyle().Bold(true)
+
+	return map[Level]lipgloss.Style{
+		TraceLevel: faint,
+		DebugLevel: faint,
+		ErrorLevel: bold,
+		PanicLevel: bold,
+		FatalLevel: bold,
+	}
+}
+
+// renderMessage applies style to each line of msg separately,
+// so multi-line messages aren't padded into a block.
+func renderMessage(r *lipgloss.Renderer, style lipgloss.Style, msg string) string {
+	style = style.Renderer(r)
+
+	lines := strings.Split(msg, "\n")
+	for i, line := range lines {
+		lines[i] = style.Render(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// setLevelStyles sets the badge style of every level bark defines in styles,
+// according to cfg, with colors picked and mapped explicitly for r's terminal.
+func setLevelStyles(styles *log.Styles, cfg config, r *lipgloss.Renderer) {
+	// Enveloped formats write the level under their own key, so the formatter mustn't.
+	if cfg.format.enveloped() {
+		clear(styles.Levels)
+		return
+	}
+
+	icons := cfg.icons.icons()
+	label := func(level Level) string {
+		if cfg.compact {
+			return cfg.compactSymbol(level)
+		}
+		return cfg.badgeLabel(level, icons)
+	}
+	levelBadge := func(level Level, dark, light string) lipgloss.Style {
+		if cfg.compact {
+			return lipgloss.NewStyle().SetString(label(level)).Foreground(badgeColor(dark, light, r))
+		}
+		return badge(label(level), dark, light, cfg.badgeStyle, r)
+	}
+
+	styles.Levels[InfoLevel] = levelBadge(InfoLevel, cfg.infoHex, cfg.infoLight)
+	styles.Levels[WarnLevel] = levelBadge(WarnLevel, cfg.warnHex, cfg.warnLight)
+	styles.Levels[ErrorLevel] = levelBadge(ErrorLevel, cfg.errorHex, cfg.errorLight)
+	styles.Levels[DebugLevel] = levelBadge(DebugLevel, cfg.debugHex, cfg.debugLight)
+	styles.Levels[FatalLevel] = levelBadge(FatalLevel, cfg.errorHex, cfg.errorLight)
+
+	// A Fatal block shares Error's color, so it also blinks to stand out.
+	if cfg.badgeStyle == BadgeBlock && !cfg.compact {
+		styles.Levels[FatalLevel] = styles.Levels[FatalLevel].Blink(true)
+	}
+
+	// Structured formats can't name bark's own levels, so write adds them as a field instead.
+	if cfg.format.structured() {
+		return
+	}
+
+	styles.Levels[TraceLevel] = levelBadge(TraceLevel, cfg.traceHex, cfg.traceLight)
+	styles.Levels[SuccessLevel] = levelBadge(SuccessLevel, cfg.successHex, cfg.successLight)
+	styles.Levels[NoticeLevel] = levelBadge(NoticeLevel, cfg.noticeHex, cfg.noticeLight)
+	styles.Levels[PanicLevel] = levelBadge(PanicLevel, cfg.panicHex, cfg.panicLight)
+	addRegisteredStyles(styles)
+
+	// Custom badge styles are used as they are, apart from getting the usual label if they have none.
+	for level, style := range cfg.levelStyles {
+		if style.Value() == "" {
+			style = style.SetString(label(level))
+		}
+		styles.Levels[level] = style
+	}
+}
+
+// badgeLabels are the names shown in the badges of bark's own levels.
+var badgeLabels = map[Level]string{
+	TraceLevel:   "TRACE",
+	DebugLevel:   "DEBUG",
+	InfoLevel:    "INFO",
+	SuccessLevel: "DONE",
+	NoticeLevel:  "NOTICE",
+	WarnLevel:    "WARN",
+	ErrorLevel:   "ERROR",
+	PanicLevel:   "PANIC",
+	FatalLevel:   "FATAL",
+}
+
+// minBadgeWidth is the width of the longest badge label, and the default badge width.
+const minBadgeWidth = 6
+
+// compactSymbols are the default symbols of bark's own levels in compact mode.
+var compactSymbols = map[Level]string{
+	TraceLevel:   "·",
+	DebugLevel:   "◆",
+	InfoLevel:    "●",
+	SuccessLevel: "✔",
+	NoticeLevel:  "◉",
+	WarnLevel:    "▲",
+	ErrorLevel:   "✖",
+	PanicLevel:   "✖",
+	FatalLevel:   "✖",
+}
+
+// errorKeys are the field keys whose values take the Error color in pretty output.
+var errorKeys = []string{"err", "error"}
+
+// setFieldStyles sets the styles of timestamps and of field keys, values, and
+// separators in styles, according to cfg, with colors picked for r's terminal.
+func setFieldStyles(styles *log.Styles, cfg config, r *lipgloss.Renderer) {
+	styles.Timestamp = cfg.timestampStyle
+	styles.Key = cfg.keyStyle
+	styles.Value = cfg.valueStyle
+
+	errorStyle := lipgloss.NewStyle().Foreground(badgeColor(cfg.errorHex, cfg.errorLight, r))
+	for _, key := range errorKeys {
+		styles.Values[key] = errorStyle
+	}
+
+	if cfg.hideSeparator {
+		styles.Separator = styles.Separator.Transform(func(sep string) string {
+			return strings.ReplaceAll(sep, "=", " ")
+		})
+	}
+}
+
+// fatalBoxStyle returns the style of the box Fatal messages are drawn in,
+// bordered in the Error color picked for r's terminal, or nil if cfg has no box.
+func fatalBoxStyle(cfg config, r *lipgloss.Renderer) *lipgloss.Style {
+	if !cfg.fatalBox || cfg.format.structured() {
+		return nil
+	}
+
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(badgeColor(cfg.errorHex, cfg.errorLight, r)).
+		Padding(0, 1)
+	return &style
+}
+
+// highlightStyle returns the style of values in formatted messages,
+// or nil if cfg doesn't highlight them.
+func highlightStyle(cfg config) *lipgloss.Style {
+	if !cfg.highlightArgs || cfg.format.structured() {
+		return nil
+	}
+	style := cfg.highlightStyle
+	return &style
+}
+
+// badgeLabel returns the text of level's badge, aligned in a cell of the configured
+// width and preceded by its icon from icons, if any.
+func (cfg config) badgeLabel(level Level, icons map[Level]string) string {
+	label := alignLabel(badgeLabels[level], cmp.Or(cfg.badgeWidth, minBadgeWidth), cfg.badgeAlign)
+	if icon, ok := icons[level]; ok {
+		return icon + " " + label
+	}
+	return label
+}
+
+// alignLabel pads label to width cells, aligned within them as align says.
+// Centered labels that can't be split evenly lean left.
+func alignLabel(label string, width int, align BadgeAlign) string {
+	pad := max(width-lipgloss.Width(label), 0)
+
+	var left int
+	switch align {
+	case AlignRight:
+		left = pad
+	case AlignCenter:
+		left = pad / 2
+	}
+	return strings.Repeat(" ", left) + label + strings.Repeat(" ", pad-left)
+}
+
+// badge returns the style of a level badge showing label in the given colors.
+// Every label is padded to the same width, so the badges line up.
+func badge(label, dark, light string, style BadgeStyle, r *lipgloss.Renderer) lipgloss.Style {
+	s := lipgloss.NewStyle().SetString(label).Padding(0, 1).Bold(true)
+
+	if style == BadgeBlock {
+		return s.Background(badgeColor(dark, light, r)).Foreground(contrastColor(dark, light, r))
+	}
+	return s.Foreground(badgeColor(dark, light, r))
+}
+
+// badgeColor returns the color of a level badge on r's terminal: dark if it has
+// a dark background and light if it has a light one, or dark on both if light is
+// empty, mapped to the nearest color r's profile supports.
+//
+// The color is picked here rather than left to an AdaptiveColor because the
+// underlying logger renders for the sink's counting writer, which it can't tell
+// is a terminal, so it would always take the dark background.
+func badgeColor(dark, light string, r *lipgloss.Renderer) lipgloss.TerminalColor {
+	return lipgloss.Color(profileColor(pickColor(dark, light, r), r.ColorProfile()))
+}
+
+// contrastColor returns black or white, whichever is more readable on
+// the badge color picked for r's terminal.
+func contrastColor(dark, light string, r *lipgloss.Renderer) lipgloss.TerminalColor {
+	return lipgloss.Color(contrastHex(pickColor(dark, light, r)))
+}
+
+// pickColor returns light if it is set and r's terminal has a light background, or dark.
+// Terminals without colors aren't asked for their background.
+func pickColor(dark, light string, r *lipgloss.Renderer) string {
+	if light == "" || r.ColorProfile() == termenv.Ascii || r.HasDarkBackground() {
+		return dark
+	}
+	return light
+}
+
+// contrastHex returns black for light colors and white for dark ones, deciding
+// by the WCAG relative luminance of color, a hex string or ANSI index.
+func contrastHex(color string) string {
+	r, g, b, err := colorRGB(color)
+	if err != nil {
+		return "#ffffff"
+	}
+
+	luminance := 0.2126*linearize(r) + 0.7152*linearize(g) + 0.0722*linearize(b)
+	// Above this, black text has the higher contrast ratio.
+	if luminance > 0.179 {
+		return "#000000"
+	}
+	return "#ffffff"
+}
+
+// linearize converts an sRGB channel value to linear light.
+func linearize(c uint8) float64 {
+	v := float64(c) / 255
+	if v <= 0.03928 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// colorRGB splits color, a hex string or ANSI index, into its channels.
+// Indices below 16 use xterm's default palette, as terminals vary.
+func colorRGB(color string) (r, g, b uint8, err error) {
+	i, ok := ansiIndex(color)
+	if !ok {
+		return parseHex(color)
+	}
+
+	switch {
+	case i < 16:
+		c := xtermColors[i]
+		return c[0], c[1], c[2], nil
+	case i < 232:
+		i -= 16
+		return uint8(cubeLevels[i/36]), uint8(cubeLevels[i/6%6]), uint8(cubeLevels[i%6]), nil
+	default:
+		gray := uint8(8 + 10*(i-232))
+		return gray, gray, gray, nil
+	}
+}
+
+// xtermColors are xterm's defaults for the 16 basic ANSI colors.
+var xtermColors = [16][3]uint8{
+	{0x00, 0x00, 0x00}, {0xcd, 0x00, 0x00}, {0x00, 0xcd, 0x00}, {0xcd, 0xcd, 0x00},
+	{0x00, 0x00, 0xee}, {0xcd, 0x00, 0xcd}, {0x00, 0xcd, 0xcd}, {0xe5, 0xe5, 0xe5},
+	{0x7f, 0x7f, 0x7f}, {0xff, 0x00, 0x00}, {0x00, 0xff, 0x00}, {0xff, 0xff, 0x00},
+	{0x5c, 0x5c, 0xff}, {0xff, 0x00, 0xff}, {0x00, 0xff, 0xff}, {0xff, 0xff, 0xff},
+}
+
+// parseHex splits a #RGB or #RRGGBB color into its channels.
+func parseHex(hex string) (r, g, b uint8, err error) {
+	digits, _ := strings.CutPrefix(hex, "#")
+	if len(digits) == 3 {
+		digits = string([]byte{digits[0], digits[0], digits[1], digits[1], digits[2], digits[2]})
+	}
+	if len(digits) != 6 {
+		return 0, 0, 0, fmt.Errorf("%q is not a #RGB or #RRGGBB color", hex)
+	}
+
+	v, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("%q is not a #RGB or #RRGGBB color", hex)
+	}
+	return uint8(v >> 16), uint8(v >> 8), uint8(v), nil
+}
@@ -0,0 +1,97 @@
+package bark_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"go.dalton.dog/bark"
+)
+
+func TestBadgeAlignmentGolden(t *testing.T) {
+	tests := []struct {
+		align bark.BadgeAlign
+		want  string
+	}{
+		{bark.AlignCenter, `
+ TRACE   message
+ DEBUG   message
+  INFO   message
+  DONE   message
+ NOTICE  message
+  WARN   message
+ ERROR   message
+ PANIC   message
+ FATAL   message
+`},
+		{bark.AlignLeft, `
+ TRACE   message
+ DEBUG   message
+ INFO    message
+ DONE    message
+ NOTICE  message
+ WARN    message
+ ERROR   message
+ PANIC   message
+ FATAL   message
+`},
+		{bark.AlignRight, `
+  TRACE  message
+  DEBUG  message
+   INFO  message
+   DONE  message
+ NOTICE  message
+   WARN  message
+  ERROR  message
+  PANIC  message
+  FATAL  message
+`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.align.String(), func(t *testing.T) {
+			var buf bytes.Buffer
+			logger, err := bark.New(
+				bark.WithOutput(&buf),
+				bark.WithTimeFormat(""),
+				bark.WithColor(bark.ColorNever),
+				bark.WithBadgeAlign(tt.align),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			logger.SetLevel(bark.TraceLevel)
+
+			logger.Trace("message")
+			logger.Debug("message")
+			logger.Info("message")
+			logger.Success("message")
+			logger.Notice("message")
+			logger.Warn("message")
+			logger.Error("message")
+			logRecovered(func() { logger.Panic("message") })
+
+			restore := bark.MockFatal()
+			logRecovered(func() { logger.Fatal("message") })
+			restore()
+
+			want := strings.TrimPrefix(tt.want, "\n")
+			if got := buf.String(); got != want {
+				t.Errorf("output:\n%s\nwant:\n%s", got, want)
+			}
+
+			// Every message starts at the same column.
+			for _, line := range strings.Split(strings.TrimSuffix(want, "\n"), "\n") {
+				if i := strings.Index(line, "message"); i != 9 {
+					t.Errorf("message in %q starts at column %d, want 9", line, i)
+				}
+			}
+		})
+	}
+}
+
+// logRecovered calls log, recovering from the panic of Panic or a mocked Fatal.
+func logRecovered(log func()) {
+	defer func() { recover() }()
+	log()
+}
@@ -0,0 +1,95 @@
+//go:build !windows && !plan9
+
+package bark
+
+import (
+	"fmt"
+	"log/syslog"
+	"strings"
+	"sync"
+)
+
+// AddSyslogLogger adds a sink sending entries to syslog alongside those configured
+// by Init. network and addr name a remote syslog server, as for syslog.Dial,
+// e.g. "udp" and "logs.example.com:514"; leave both empty for the local daemon.
+// priority gives the facility, and the severity used for lines whose level is
+// unknown; tag is the program name syslog records.
+//
+// Entries are written as uncolored logfmt without a timestamp, which syslog adds,
+// at the severity matching their level: Trace and Debug at LOG_DEBUG, Info and
+// Success at LOG_INFO, Notice at LOG_NOTICE, Warn at LOG_WARNING, Error at LOG_ERR,
+// and Panic and Fatal at LOG_CRIT. Like AddWriterLogger, the sink is replaced
+// by the next Init, and closed by Reset and Shutdown.
+func AddSyslogLogger(network, addr string, priority syslog.Priority, tag string) error {
+	w, err := syslog.Dial(network, addr, priority, tag)
+	if err != nil {
+		return fmt.Errorf("AddSyslogLogger: %v", err)
+	}
+
+	err = AddWriterLogger(&syslogWriter{w: w}, WithFormat(FormatLogfmt), WithTimeFormat(""), WithColor(ColorNever))
+	if err != nil {
+		w.Close()
+		return err
+	}
+	return nil
+}
+
+// syslogWriter sends each line written to it to syslog at the severity of the
+// level of the entry being written, as given by startEntry.
+type syslogWriter struct {
+	w *syslog.Writer
+
+	// mu is held from startEntry to endEntry, so level stays the entry's.
+	mu       sync.Mutex
+	level    Level
+	hasLevel bool
+}
+
+func (s *syslogWriter) startEntry(level Level) {
+	s.mu.Lock()
+	s.level, s.hasLevel = level, true
+}
+
+func (s *syslogWriter) endEntry() {
+	s.hasLevel = false
+	s.mu.Unlock()
+}
+
+// Write sends p to syslog. Lines written outside an entry get the default severity.
+func (s *syslogWriter) Write(p []byte) (int, error) {
+	line := strings.TrimRight(string(p), "\n")
+
+	var err error
+	switch level := s.level; {
+	case !s.hasLevel:
+		_, err = s.w.Write([]byte(line))
+	case level == PrintLevel:
+		err = s.w.Info(line)
+	case level >= PanicLevel:
+		err = s.w.Crit(line)
+	case level >= ErrorLevel:
+		err = s.w.Err(line)
+	case level >= WarnLevel:
+		err = s.w.Warning(line)
+	case level >= NoticeLevel:
+		err = s.w.Notice(line)
+	case level >= InfoLevel:
+		err = s.w.Info(line)
+	default:
+		err = s.w.Debug(line)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close closes the connection to syslog.
+func (s *syslogWriter) Close() error {
+	return s.w.Close()
+}
+
+func (s *syslogWriter) sinkKind() string {
+	return "syslog"
+}
@@ -0,0 +1,206 @@
+package bark
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// FatalExit is the value Fatal and Fatalf panic with while MockFatal is in effect.
+type FatalExit struct {
+	// Message is the fatal message.
+	Message string
+	// Code is the status the program would have exited with.
+	Code int
+}
+
+// Error makes FatalExit usable as an error, e.g. in test failure messages.
+func (f FatalExit) Error() string {
+	return fmt.Sprintf("fatal (exit %d): %s", f.Code, f.Message)
+}
+
+// MockFatal makes Fatal and Fatalf panic with a FatalExit instead of exiting
+// the process, so code that calls them can be tested. Fatal hooks still run and
+// the sinks are still flushed. It returns a function that restores the original
+// behavior:
+//
+//	func TestLoadConfigFails(t *testing.T) {
+//		restore := bark.MockFatal()
+//		defer restore()
+//
+//		defer func() {
+//			fatal, ok := recover().(bark.FatalExit)
+//			if !ok {
+//				t.Fatal("expected bark.Fatal to be called")
+//			}
+//			if !strings.Contains(fatal.Message, "config") {
+//				t.Errorf("unexpected fatal message %q", fatal.Message)
+//			}
+//		}()
+//
+//		loadConfig("missing.toml") // calls bark.Fatal
+//	}
+//
+// MockFatal affects the whole process, so it should only be used by tests
+// that don't run in parallel with other tests relying on Fatal.
+func MockFatal() (restore func()) {
+	exitMu.Lock()
+	defer exitMu.Unlock()
+
+	previous := fatalExit
+	fatalExit = func(msg string, code int) {
+		panic(FatalExit{Message: msg, Code: code})
+	}
+
+	return func() {
+		exitMu.Lock()
+		defer exitMu.Unlock()
+		fatalExit = previous
+	}
+}
+
+// CapturedEntry is a log entry recorded by a TestCapture or kept by EnableRingBuffer.
+type CapturedEntry struct {
+	Level   Level
+	Message string
+	Fields  []any
+}
+
+// TestCapture records the entries written to a test sink.
+// It is safe for concurrent use.
+type TestCapture struct {
+	mu      sync.Mutex
+	entries []CapturedEntry
+	output  bytes.Buffer
+}
+
+// NewTestLogger returns a Logger that writes only to a TestCapture, so tests can
+// assert on what was logged. Entries are recorded at Debug level and above,
+// without timestamps or ANSI colors, and messages are kept as plain strings
+// suitable for strings.Contains.
+func NewTestLogger() (*Logger, *TestCapture) {
+	s, capture := newCaptureSink()
+
+	logger := &Logger{level: &levelVar{}, sinks: []*sink{s}}
+	logger.SetLevel(DebugLevel)
+
+	return logger, capture
+}
+
+// CaptureGlobal replaces every global sink with a TestCapture, so tests can assert
+// on output from code that uses the package-level functions or named Loggers,
+// without modifying that code. The global level still applies. Calling restore
+// puts the original sinks back:
+//
+//	restore, capture := bark.CaptureGlobal()
+//	defer restore()
+//
+// Because the sinks are process-wide, CaptureGlobal is only safe for tests that
+// run sequentially; don't use it from tests that call t.Parallel.
+func CaptureGlobal() (restore func(), capture *TestCapture) {
+	// Make sure there is a configuration to restore.
+	currentSinks()
+
+	s, capture := newCaptureSink()
+
+	sinksMu.Lock()
+	previous := sinks
+	sinks = []*sink{s}
+	sinksMu.Unlock()
+
+	restore = func() {
+		sinksMu.Lock()
+		defer sinksMu.Unlock()
+		sinks = previous
+	}
+
+	return restore, capture
+}
+
+// newCaptureSink creates a sink that records entries in a new TestCapture,
+// rendering them as plain text without timestamps.
+func newCaptureSink() (*sink, *TestCapture) {
+	capture := &TestCapture{}
+
+	cfg, _ := newConfig(WithTimeFormat(""))
+	s := newSink(captureWriter{capture}, cfg)
+	s.capture = capture
+
+	return s, capture
+}
+
+// Entries returns a copy of the entries recorded so far, oldest first.
+func (c *TestCapture) Entries() []CapturedEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]CapturedEntry(nil), c.entries...)
+}
+
+// String returns everything written so far, rendered as plain text.
+func (c *TestCapture) String() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.output.String()
+}
+
+// Reset discards every recorded entry.
+func (c *TestCapture) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = nil
+	c.output.Reset()
+}
+
+// record appends an entry.
+func (c *TestCapture) record(level Level, msg string, fields []any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = append(c.entries, CapturedEntry{
+		Level:   level,
+		Message: msg,
+		Fields:  append([]any(nil), fields...),
+	})
+}
+
+// captureWriter collects a TestCapture's rendered output.
+type captureWriter struct {
+	capture *TestCapture
+}
+
+func (w captureWriter) Write(p []byte) (int, error) {
+	w.capture.mu.Lock()
+	defer w.capture.mu.Unlock()
+	return w.capture.output.Write(p)
+}
+
+// AssertLogged fails the test unless c recorded an entry at level whose
+// message contains msg. The failure message includes everything c captured.
+func AssertLogged(t testing.TB, c *TestCapture, level Level, msg string) {
+	t.Helper()
+
+	if !c.contains(level, msg) {
+		t.Errorf("expected a %s entry containing %q, captured:\n%s", levelName(level), msg, c.String())
+	}
+}
+
+// AssertNotLogged fails the test if c recorded an entry at level whose
+// message contains msg. The failure message includes everything c captured.
+func AssertNotLogged(t testing.TB, c *TestCapture, level Level, msg string) {
+	t.Helper()
+
+	if c.contains(level, msg) {
+		t.Errorf("expected no %s entry containing %q, captured:\n%s", levelName(level), msg, c.String())
+	}
+}
+
+// contains reports whether c recorded an entry at level whose message contains msg.
+func (c *TestCapture) contains(level Level, msg string) bool {
+	for _, entry := range c.Entries() {
+		if entry.Level == level && strings.Contains(entry.Message, msg) {
+			return true
+		}
+	}
+	return false
+}
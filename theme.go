@@ -0,0 +1,101 @@
+package bark
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Themes are ready-made color sets for Init. Each maps the theme's palette onto
+// the levels (Info blue, Warn yellow, Error red, Debug purple, and so on) and
+// carries both the dark variant and the light one, picked by terminal background.
+// Later options override a theme's colors:
+//
+//	bark.Init(bark.ThemeNord, bark.WithErrorColor("#ff0000"))
+var (
+	// ThemeDracula uses Dracula on dark backgrounds and Alucard on light ones.
+	ThemeDracula = BarkOptions{
+		InfoHex: "#8be9fd", WarnHex: "#f1fa8c", ErrorHex: "#ff5555", DebugHex: "#bd93f9",
+		TraceHex: "#6272a4", SuccessHex: "#50fa7b", NoticeHex: "#ff79c6", PanicHex: "#ff5555",
+
+		InfoHexLight: "#036a96", WarnHexLight: "#846e15", ErrorHexLight: "#cb3a2a", DebugHexLight: "#644ac9",
+		TraceHexLight: "#6c664b", SuccessHexLight: "#14710a", NoticeHexLight: "#a3144d", PanicHexLight: "#cb3a2a",
+	}
+
+	// ThemeNord uses Nord's frost and aurora colors.
+	ThemeNord = BarkOptions{
+		InfoHex: "#88c0d0", WarnHex: "#ebcb8b", ErrorHex: "#bf616a", DebugHex: "#b48ead",
+		TraceHex: "#616e88", SuccessHex: "#a3be8c", NoticeHex: "#81a1c1", PanicHex: "#d08770",
+
+		InfoHexLight: "#5e81ac", WarnHexLight: "#a07e3b", ErrorHexLight: "#a5404a", DebugHexLight: "#8c6a88",
+		TraceHexLight: "#4c566a", SuccessHexLight: "#6f8a57", NoticeHexLight: "#3b6e8f", PanicHexLight: "#b0573d",
+	}
+
+	// ThemeSolarized uses Solarized's accents, which are shared by its dark and
+	// light variants; only Trace's base tone differs.
+	ThemeSolarized = BarkOptions{
+		InfoHex: "#268bd2", WarnHex: "#b58900", ErrorHex: "#dc322f", DebugHex: "#6c71c4",
+		TraceHex: "#839496", SuccessHex: "#859900", NoticeHex: "#2aa198", PanicHex: "#d33682",
+
+		InfoHexLight: "#268bd2", WarnHexLight: "#b58900", ErrorHexLight: "#dc322f", DebugHexLight: "#6c71c4",
+		TraceHexLight: "#657b83", SuccessHexLight: "#859900", NoticeHexLight: "#2aa198", PanicHexLight: "#d33682",
+	}
+
+	// ThemeCatppuccin uses Catppuccin Mocha on dark backgrounds and Latte on light ones.
+	ThemeCatppuccin = BarkOptions{
+		InfoHex: "#89b4fa", WarnHex: "#f9e2af", ErrorHex: "#f38ba8", DebugHex: "#cba6f7",
+		TraceHex: "#7f849c", SuccessHex: "#a6e3a1", NoticeHex: "#89dceb", PanicHex: "#eba0ac",
+
+		InfoHexLight: "#1e66f5", WarnHexLight: "#df8e1d", ErrorHexLight: "#d20f39", DebugHexLight: "#8839ef",
+		TraceHexLight: "#8c8fa1", SuccessHexLight: "#40a02b", NoticeHexLight: "#04a5e5", PanicHexLight: "#e64553",
+	}
+
+	// ThemeGruvbox uses Gruvbox's bright colors on dark backgrounds and its faded ones on light.
+	ThemeGruvbox = BarkOptions{
+		InfoHex: "#83a598", WarnHex: "#fabd2f", ErrorHex: "#fb4934", DebugHex: "#d3869b",
+		TraceHex: "#a89984", SuccessHex: "#b8bb26", NoticeHex: "#8ec07c", PanicHex: "#fe8019",
+
+		InfoHexLight: "#076678", WarnHexLight: "#b57614", ErrorHexLight: "#9d0006", DebugHexLight: "#8f3f71",
+		TraceHexLight: "#7c6f64", SuccessHexLight: "#79740e", NoticeHexLight: "#427b58", PanicHexLight: "#af3a03",
+	}
+
+	// ThemeTokyoNight uses Tokyo Night on dark backgrounds and Tokyo Night Day on light ones.
+	ThemeTokyoNight = BarkOptions{
+		InfoHex: "#7aa2f7", WarnHex: "#e0af68", ErrorHex: "#f7768e", DebugHex: "#bb9af7",
+		TraceHex: "#565f89", SuccessHex: "#9ece6a", NoticeHex: "#7dcfff", PanicHex: "#ff9e64",
+
+		InfoHexLight: "#2e7de9", WarnHexLight: "#8c6c3e", ErrorHexLight: "#f52a65", DebugHexLight: "#9854f1",
+		TraceHexLight: "#848cb5", SuccessHexLight: "#587539", NoticeHexLight: "#007197", PanicHexLight: "#b15c00",
+	}
+)
+
+// themes maps the names accepted by ParseTheme to their themes.
+var themes = map[string]*BarkOptions{
+	"dracula":    &ThemeDracula,
+	"nord":       &ThemeNord,
+	"solarized":  &ThemeSolarized,
+	"catppuccin": &ThemeCatppuccin,
+	"gruvbox":    &ThemeGruvbox,
+	"tokyonight": &ThemeTokyoNight,
+}
+
+// Themes returns the names of the built-in themes in alphabetical order,
+// e.g. to list the choices of a --theme flag.
+func Themes() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// ParseTheme returns the built-in theme called name, compared case-insensitively.
+// The error for an unknown name lists the valid ones.
+func ParseTheme(name string) (BarkOptions, error) {
+	theme, ok := themes[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return BarkOptions{}, fmt.Errorf("unknown theme %q, valid themes are %s", name, strings.Join(Themes(), ", "))
+	}
+	return *theme, nil
+}
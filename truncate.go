@@ -0,0 +1,49 @@
+package bark
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// clipMarker stands in for the message when an entry is rendered to measure
+// the rest of its line. It is one cell wide and unlikely to appear otherwise.
+const clipMarker = "￼"
+
+// ellipsis ends clipped messages.
+const ellipsis = "…"
+
+// clipMessage clips the lines of msg, an already styled pretty message, so that
+// its entry fits the terminal's width without wrapping. line is the entry
+// rendered with clipMarker in place of msg, giving the width of what precedes
+// msg on its first line and follows it on its last. Widths are measured in
+// cells, so wide characters and escape sequences are never cut in half.
+// s.mu must be held.
+func (s *sink) clipMessage(msg, line string) string {
+	before, after, ok := strings.Cut(strings.TrimSuffix(line, "\n"), clipMarker)
+	if !ok {
+		return msg
+	}
+	// Multi-line field values continue on lines of their own.
+	after, _, _ = strings.Cut(after, "\n")
+
+	width := lineWidth(s.out)
+	tail := lipgloss.NewStyle().Faint(true).Renderer(s.renderer).Render(ellipsis)
+
+	lines := strings.Split(msg, "\n")
+	for i, l := range lines {
+		available := width
+		if i == 0 {
+			available -= lipgloss.Width(before)
+		}
+		if i == len(lines)-1 {
+			available -= lipgloss.Width(after)
+		}
+		if lipgloss.Width(l) <= available {
+			continue
+		}
+		lines[i] = ansi.Truncate(l, max(available, lipgloss.Width(ellipsis)), tail)
+	}
+	return strings.Join(lines, "\n")
+}
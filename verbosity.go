@@ -0,0 +1,86 @@
+package bark
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// defaultVerbosityLadder maps verbosity counts to levels.
+var defaultVerbosityLadder = map[int]Level{
+	-2: ErrorLevel,
+	-1: WarnLevel,
+	0:  InfoLevel,
+	1:  DebugLevel,
+	2:  TraceLevel,
+}
+
+var (
+	verbosityMu     sync.RWMutex
+	verbosityLadder = defaultVerbosityLadder
+)
+
+// SetVerbosity sets the global level from a count of -v flags, as command line
+// tools conventionally take them: 0 is Info, 1 (-v) Debug, and 2 (-vv) Trace,
+// while -1 is Warn and -2 Error, e.g. for counting -q flags. Counts beyond the
+// ends of the ladder use the level at that end. The ladder can be replaced
+// with SetVerbosityLadder.
+func SetVerbosity(n int) {
+	verbosityMu.RLock()
+	ladder := verbosityLadder
+	verbosityMu.RUnlock()
+
+	steps := slices.Sorted(maps.Keys(ladder))
+	n = max(steps[0], min(n, steps[len(steps)-1]))
+
+	// Fall back to the nearest count toward zero for gaps in the ladder.
+	for {
+		if level, ok := ladder[n]; ok {
+			SetLevel(level)
+			return
+		}
+		if n > 0 {
+			n--
+		} else {
+			n++
+		}
+	}
+}
+
+// SetVerbosityLadder replaces the levels SetVerbosity maps counts to, for
+// programs with their own conventions. ladder must include a level for 0.
+// A nil ladder restores the default.
+func SetVerbosityLadder(ladder map[int]Level) error {
+	if ladder == nil {
+		ladder = defaultVerbosityLadder
+	}
+	if _, ok := ladder[0]; !ok {
+		return fmt.Errorf("SetVerbosityLadder: ladder has no level for 0")
+	}
+
+	verbosityMu.Lock()
+	defer verbosityMu.Unlock()
+	verbosityLadder = maps.Clone(ladder)
+
+	return nil
+}
+
+// CountVerbosity counts the -v flags in args, such as os.Args[1:], for tools that
+// don't use a flag library: "-v" counts one, "-vv" two, "-vvv" three, and
+// "--verbose" one. Arguments after "--" are ignored.
+func CountVerbosity(args []string) int {
+	count := 0
+	for _, arg := range args {
+		switch {
+		case arg == "--":
+			return count
+		case arg == "--verbose":
+			count++
+		case len(arg) > 1 && arg[0] == '-' && strings.Trim(arg[1:], "v") == "":
+			count += len(arg) - 1
+		}
+	}
+	return count
+}
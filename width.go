@@ -0,0 +1,30 @@
+package bark
+
+import (
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/mattn/go-isatty"
+)
+
+// defaultWidth is the line width assumed when the terminal's can't be detected.
+const defaultWidth = 80
+
+// lineWidth returns the width in columns available on w: the terminal's if w is one,
+// otherwise $COLUMNS if set, otherwise defaultWidth.
+func lineWidth(w io.Writer) int {
+	if width := terminalWidth(w); width > 0 {
+		return width
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return defaultWidth
+}
+
+// isTerminal reports whether w writes to a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && (isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd()))
+}
@@ -0,0 +1,11 @@
+//go:build !unix
+
+package bark
+
+import "io"
+
+// terminalWidth returns 0, as terminal sizes are only detected on Unix.
+// Callers fall back to $COLUMNS or a default.
+func terminalWidth(io.Writer) int {
+	return 0
+}